@@ -0,0 +1,83 @@
+package main
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// anagramFinder is implemented by dictionaries that can look up anagrams.
+type anagramFinder interface {
+	Anagrams(letters string, subset bool) []string
+}
+
+// letterCounts is a multiset of the runes in a string.
+type letterCounts struct {
+	counts    map[rune]int
+	remaining int
+}
+
+func newLetterCounts(letters string) *letterCounts {
+	counts := make(map[rune]int)
+	for _, c := range letters {
+		counts[c]++
+	}
+	return &letterCounts{counts, utf8.RuneCountInString(letters)}
+}
+
+func (lc *letterCounts) take(c rune) bool {
+	if lc.counts[c] == 0 {
+		return false
+	}
+	lc.counts[c]--
+	lc.remaining--
+	return true
+}
+
+func (lc *letterCounts) putBack(c rune) {
+	lc.counts[c]++
+	lc.remaining++
+}
+
+// Anagrams returns, in lexicographic order, the keys that use exactly the given letters, or with
+// subset set, the keys that use only letters from them (each no more often than given). Only
+// branches spelled from the remaining letters are walked.
+func (t *ValueTrie[V]) Anagrams(letters string, subset bool) []string {
+	available := newLetterCounts(letters)
+	var anagrams []string
+	var walk func(node *ValueTrie[V], key []byte)
+	walk = func(node *ValueTrie[V], key []byte) {
+		if node.isKey && len(key) > 0 && (subset || available.remaining == 0) {
+			anagrams = append(anagrams, string(key))
+		}
+		for c, child := range node.children {
+			if available.take(c) {
+				walk(child, utf8.AppendRune(key, c))
+				available.putBack(c)
+			}
+		}
+	}
+	walk(t, []byte{})
+	slices.Sort(anagrams)
+	return anagrams
+}
+
+// Anagrams returns, in lexicographic order, the keys that use exactly the given letters, or with
+// subset set, the keys that use only letters from them (each no more often than given).
+func (t *DawgNode) Anagrams(letters string, subset bool) []string {
+	available := newLetterCounts(letters)
+	var anagrams []string
+	var walk func(node *DawgNode, key []byte)
+	walk = func(node *DawgNode, key []byte) {
+		if node.isKey && len(key) > 0 && (subset || available.remaining == 0) {
+			anagrams = append(anagrams, string(key))
+		}
+		for _, edge := range node.edges {
+			if available.take(edge.c) {
+				walk(edge.node, utf8.AppendRune(key, edge.c))
+				available.putBack(edge.c)
+			}
+		}
+	}
+	walk(t, []byte{})
+	return anagrams
+}
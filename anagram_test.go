@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnagrams(t *testing.T) {
+	wordList := []string{"listen", "silent", "enlist", "tinsel", "list", "lint", "tin", "inlets", "listens"}
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+	expected := []string{"enlist", "inlets", "listen", "silent", "tinsel"}
+	actual := trie.Anagrams("silent", false)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	expected = []string{"lint", "list", "tin"}
+	actual = trie.Anagrams("tsinl", true)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	if actual := trie.Anagrams("tt", true); len(actual) > 0 {
+		t.Fatalf("Expected letters to be used at most as often as given, got %v", actual)
+	}
+}
+
+func TestAnagramsDawg(t *testing.T) {
+	wordList := []string{"listen", "silent", "enlist", "tinsel", "list", "lint", "tin"}
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+	dawg := newDawgNode()
+	dawg.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+	for _, subset := range []bool{false, true} {
+		expected := trie.Anagrams("listen", subset)
+		actual := dawg.Anagrams("listen", subset)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+		}
+	}
+}
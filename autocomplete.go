@@ -0,0 +1,86 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Completion is a key completing a typed input, which is distance edits from one of the key's prefixes.
+type Completion struct {
+	key       string
+	distance  int
+	frequency int
+}
+
+// FuzzyComplete returns the keys that complete a prefix within maxDistance insertions, deletions
+// or substitutions of input, so "thse" completes to "these" and "thesaurus". They are ranked by
+// distance, then by descending frequency of their values, then lexicographically, and at most
+// limit are returned, or all of them when limit is zero. frequency may be nil to rank only by
+// distance. An input no longer than maxDistance is within reach of the empty prefix, so every
+// key completes it.
+func (t *ValueTrie[V]) FuzzyComplete(input string, maxDistance int, limit int, frequency func(value V) int) []Completion {
+	chars := []rune(input)
+	var completions []Completion
+	// row[i] is the edit distance from the first i runes of input to the path leading to a node
+	row := make([]int, len(chars)+1)
+	for i := range row {
+		row[i] = i
+	}
+	var visit func(node *ValueTrie[V], key []byte, row []int, best int)
+	visit = func(node *ValueTrie[V], key []byte, row []int, best int) {
+		// best is the smallest distance from input to any prefix of key seen so far
+		if row[len(chars)] < best {
+			best = row[len(chars)]
+		}
+		if node.isKey && best <= maxDistance {
+			completion := Completion{key: string(key), distance: best}
+			if frequency != nil {
+				completion.frequency = frequency(node.value)
+			}
+			completions = append(completions, completion)
+		}
+		// distances only grow along a path, so a branch whose whole row is out of reach can't
+		// bring any prefix within it
+		if best > maxDistance && slices.Min(row) > maxDistance {
+			return
+		}
+		for c, child := range node.children {
+			next := make([]int, len(row))
+			next[0] = row[0] + 1
+			for i := 1; i < len(row); i++ {
+				substitution := row[i-1]
+				if chars[i-1] != c {
+					substitution++
+				}
+				next[i] = minInt(substitution, minInt(row[i], next[i-1])+1)
+			}
+			visit(child, utf8.AppendRune(key, c), next, best)
+		}
+	}
+	visit(t, []byte{}, row, len(chars)+1)
+	slices.SortFunc(completions, func(a, b Completion) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		if a.frequency != b.frequency {
+			return b.frequency - a.frequency
+		}
+		return strings.Compare(a.key, b.key)
+	})
+	if limit > 0 && len(completions) > limit {
+		completions = completions[:limit]
+	}
+	return completions
+}
+
+// newFrequencyTrie reads a word list into a trie counting how often each word appears, so a list
+// of the words of a corpus ranks common words first.
+func newFrequencyTrie(words []string) *ValueTrie[int] {
+	trie := newValueTrie[int]()
+	for _, word := range words {
+		frequency, _ := trie.Get(word)
+		trie.Put(word, frequency+1)
+	}
+	return trie
+}
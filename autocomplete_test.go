@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func completionKeys(completions []Completion) []string {
+	keys := make([]string, len(completions))
+	for i, completion := range completions {
+		keys[i] = completion.key
+	}
+	return keys
+}
+
+func TestFuzzyComplete(t *testing.T) {
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader("these\nthesaurus\nzebra\nthis\ntheory"))
+	actual := trie.FuzzyComplete("thse", 1, 0, nil)
+	// "these" is one insertion from "thse" and the others complete "the", one deletion away;
+	// "this" is two edits from any of its prefixes
+	expected := []Completion{
+		{key: "theory", distance: 1},
+		{key: "thesaurus", distance: 1},
+		{key: "these", distance: 1},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	if actual := trie.FuzzyComplete("thse", 0, 0, nil); len(actual) != 0 {
+		t.Fatalf("Expected no exact completions, got %v", actual)
+	}
+	if actual := completionKeys(trie.FuzzyComplete("thes", 0, 0, nil)); !reflect.DeepEqual(actual, []string{"thesaurus", "these"}) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", []string{"thesaurus", "these"}, actual)
+	}
+}
+
+func TestFuzzyComplete_Ranking(t *testing.T) {
+	trie := newFrequencyTrie([]string{"thesaurus", "these", "these", "thesis", "thesis", "thesis", "thus", "zebra"})
+	actual := trie.FuzzyComplete("thes", 1, 3, func(frequency int) int { return frequency })
+	expected := []Completion{
+		{key: "thesis", distance: 0, frequency: 3},
+		{key: "these", distance: 0, frequency: 2},
+		{key: "thesaurus", distance: 0, frequency: 1},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	actual = trie.FuzzyComplete("thes", 1, 0, func(frequency int) int { return frequency })
+	if last := actual[len(actual)-1]; last.key != "thus" || last.distance != 1 {
+		t.Fatalf("Expected the more distant thus last, got %v", actual)
+	}
+	for _, completion := range actual {
+		if completion.key == "zebra" {
+			t.Fatalf("Did not expect zebra to complete thes, got %v", actual)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"io"
+	"math"
+)
+
+// bloomFalsePositiveRate is the rate filters are sized for: about 1 in 100 absent keys is
+// reported as possibly present.
+const bloomFalsePositiveRate = 0.01
+
+// bloomMinCapacity is the number of keys an empty filter is sized for before its first InsertAll.
+const bloomMinCapacity = 1024
+
+// BloomFilter is a bit-packed set of keys that answers "definitely absent" or "possibly present"
+// in a few memory reads, using about 10 bits per key at a 1% false positive rate.
+type BloomFilter struct {
+	bits   []uint64
+	hashes int
+	// capacity is the number of keys the filter was sized for
+	capacity int
+}
+
+// newBloomFilter creates a filter sized for capacity keys at bloomFalsePositiveRate.
+func newBloomFilter(capacity int) *BloomFilter {
+	if capacity < bloomMinCapacity {
+		capacity = bloomMinCapacity
+	}
+	nBits := math.Ceil(-float64(capacity) * math.Log(bloomFalsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := int(math.Round(nBits / float64(capacity) * math.Ln2))
+	return &BloomFilter{
+		bits:     make([]uint64, (int(nBits)+63)/64),
+		hashes:   hashes,
+		capacity: capacity,
+	}
+}
+
+// hashKey returns the 64-bit FNV-1a hash of key.
+func hashKey(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// positions calls visit with the bit index of each of the filter's hashes of key, derived from
+// the two halves of one hash as in Kirsch and Mitzenmacher, "Less Hashing, Same Performance".
+func (f *BloomFilter) positions(key string, visit func(bit uint64) bool) bool {
+	h := hashKey(key)
+	h1, h2 := h&math.MaxUint32, h>>32|1
+	nBits := uint64(len(f.bits)) * 64
+	for i := uint64(0); i < uint64(f.hashes); i++ {
+		if !visit((h1 + i*h2) % nBits) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *BloomFilter) Add(key string) {
+	f.positions(key, func(bit uint64) bool {
+		f.bits[bit/64] |= 1 << (bit % 64)
+		return true
+	})
+}
+
+// MayContain returns false if key was never added, and true if it probably was.
+func (f *BloomFilter) MayContain(key string) bool {
+	return f.positions(key, func(bit uint64) bool {
+		return f.bits[bit/64]&(1<<(bit%64)) != 0
+	})
+}
+
+// FilteredTrie consults a Bloom filter before its trie, so keys that are not in the dictionary
+// are usually rejected without walking the trie. All other queries go to the trie.
+type FilteredTrie struct {
+	Trie
+	filter *BloomFilter
+}
+
+func newFilteredTrie(trie Trie) *FilteredTrie {
+	filtered := &FilteredTrie{Trie: trie}
+	filtered.rebuildFilter(trie.Size())
+	return filtered
+}
+
+// rebuildFilter replaces the filter with one sized for capacity keys holding the keys now in the trie.
+func (t *FilteredTrie) rebuildFilter(capacity int) {
+	t.filter = newBloomFilter(capacity)
+	t.Trie.Walk("", func(key string) bool {
+		t.filter.Add(key)
+		return true
+	})
+}
+
+// Insert adds key to the trie and the filter. Once the trie holds more keys than the filter was
+// sized for, the filter is rebuilt for twice as many, so its false positive rate stays near
+// bloomFalsePositiveRate and rebuilds take amortized constant time per key.
+func (t *FilteredTrie) Insert(key string) bool {
+	inserted := t.Trie.Insert(key)
+	if size := t.Trie.Size(); size > t.filter.capacity {
+		t.rebuildFilter(2 * size)
+	} else {
+		t.filter.Add(key)
+	}
+	return inserted
+}
+
+func (t *FilteredTrie) InsertAll(r io.Reader) LoadReport {
+	report := insertWordList(t.Trie, r)
+	t.rebuildFilter(t.Trie.Size())
+	return report
+}
+
+// DeleteAll removes the keys read from r. Single deletes leave their keys in the filter, which
+// only costs a trie lookup, but bulk deletes rebuild it.
+func (t *FilteredTrie) DeleteAll(r io.Reader) LoadReport {
+	report := t.Trie.DeleteAll(r)
+	t.rebuildFilter(t.Trie.Size())
+	return report
+}
+
+func (t *FilteredTrie) Contains(key string) bool {
+	return t.filter.MayContain(key) && t.Trie.Contains(key)
+}
+
+// Anagrams looks up anagrams in the wrapped trie, or returns none if it can't look them up.
+func (t *FilteredTrie) Anagrams(letters string, subset bool) []string {
+	if finder, ok := t.Trie.(anagramFinder); ok {
+		return finder.Anagrams(letters, subset)
+	}
+	return nil
+}
+
+// MembershipFilter is a membership-only dictionary for checks that never need suggestions: it
+// keeps just a Bloom filter instead of a trie. Contains may accept about 1% of misspellings, and
+// prefix queries find nothing. Keys can't be deleted.
+//
+// The filter is sized by the first InsertAll, or for bloomMinCapacity keys if Insert comes first,
+// and can't be rebuilt later because it doesn't keep the keys. Past that capacity the false
+// positive rate grows with every key, to about 16% at twice the capacity, so load the whole
+// dictionary with a single InsertAll.
+type MembershipFilter struct {
+	filter *BloomFilter
+	size   int
+}
+
+func newMembershipFilter() *MembershipFilter {
+	return &MembershipFilter{filter: newBloomFilter(0)}
+}
+
+// Insert adds key to the filter, which raises the false positive rate once the filter is full.
+func (t *MembershipFilter) Insert(key string) bool {
+	if !t.filter.MayContain(key) {
+		t.size++
+	}
+	t.filter.Add(key)
+	return true
+}
+
+// InsertAll adds the newline-separated keys read from r. An empty filter is first resized for them.
+func (t *MembershipFilter) InsertAll(r io.Reader) LoadReport {
+	keys, report := readWords(r)
+	if t.size == 0 {
+		t.filter = newBloomFilter(len(keys))
+	}
+	for _, key := range keys {
+		_ = t.Insert(key)
+	}
+	return report
+}
+
+// Delete is not supported by the filter and returns false.
+func (t *MembershipFilter) Delete(key string) bool {
+	return false
+}
+
+// DeleteAll is not supported by the filter, which it leaves unchanged.
+func (t *MembershipFilter) DeleteAll(r io.Reader) LoadReport {
+	return LoadReport{err: errNoDelete}
+}
+
+func (t *MembershipFilter) Contains(key string) bool {
+	return t.filter.MayContain(key)
+}
+
+func (t *MembershipFilter) LongestPrefix(key string) string {
+	return ""
+}
+
+func (t *MembershipFilter) KeysWithCommonPrefix(prefix string) []string {
+	return []string{}
+}
+
+func (t *MembershipFilter) Walk(prefix string, visit func(key string) bool) {
+}
+
+func (t *MembershipFilter) CountWithPrefix(prefix string) int {
+	if prefix == "" {
+		return t.size
+	}
+	return 0
+}
+
+// Size returns the number of keys added, not counting those the filter already reported as present.
+func (t *MembershipFilter) Size() int {
+	return t.size
+}
+
+func (t *MembershipFilter) Enumerate() []string {
+	return nil
+}
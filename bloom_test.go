@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	filter := newBloomFilter(10000)
+	for i := 0; i < 10000; i++ {
+		filter.Add("word" + strconv.Itoa(i))
+	}
+	for i := 0; i < 10000; i++ {
+		if !filter.MayContain("word" + strconv.Itoa(i)) {
+			t.Fatalf("Expected filter to contain word%d", i)
+		}
+	}
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if filter.MayContain("absent" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	// allow twice the rate the filter is sized for
+	if falsePositives > 200 {
+		t.Fatalf("Expected about 1%% false positives, got %d in 10000", falsePositives)
+	}
+}
+
+func TestFilteredTrie(t *testing.T) {
+	trie := newFilteredTrie(newTrieNode())
+	trie.InsertAll(strings.NewReader("the\nthem\nthese\nzebra"))
+	trie.Insert("theses")
+	for _, key := range []string{"the", "them", "these", "theses", "zebra"} {
+		if !trie.Contains(key) {
+			t.Fatalf("Expected trie to contain %v", key)
+		}
+	}
+	trie.Delete("zebra")
+	if trie.Contains("zebra") || trie.Contains("thes") || trie.Contains("zebras") {
+		t.Fatalf("Did not expect trie to contain deleted keys or prefixes")
+	}
+	if actual := trie.KeysWithCommonPrefix("thes"); len(actual) != 2 {
+		t.Fatalf("Expected prefix queries to use the trie, got %v", actual)
+	}
+}
+
+func TestFilteredTrie_Grow(t *testing.T) {
+	trie := newFilteredTrie(newTrieNode())
+	for i := 0; i < 5000; i++ {
+		trie.Insert("word" + strconv.Itoa(i))
+	}
+	if trie.filter.capacity < trie.Size() {
+		t.Fatalf("Expected the filter to grow past %d keys, got capacity %d", trie.Size(), trie.filter.capacity)
+	}
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if trie.filter.MayContain("absent" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 200 {
+		t.Fatalf("Expected about 1%% false positives, got %d in 10000", falsePositives)
+	}
+	for i := 0; i < 5000; i++ {
+		if !trie.Contains("word" + strconv.Itoa(i)) {
+			t.Fatalf("Expected trie to contain word%d", i)
+		}
+	}
+}
+
+func TestFilteredTrie_NonEmpty(t *testing.T) {
+	inner := newTrieNode()
+	inner.InsertAll(strings.NewReader("the\nzebra"))
+	if trie := newFilteredTrie(inner); !trie.Contains("the") || !trie.Contains("zebra") {
+		t.Fatalf("Expected the filter to hold the keys already in the trie")
+	}
+}
+
+func TestMembershipFilter(t *testing.T) {
+	trie := newMembershipFilter()
+	trie.InsertAll(strings.NewReader("the\nthem\nthese\nthe"))
+	if !trie.Contains("the") || !trie.Contains("these") || trie.Size() != 3 {
+		t.Fatalf("Expected filter to contain the inserted keys, got size %d", trie.Size())
+	}
+	if trie.Delete("the") || !trie.Contains("the") {
+		t.Fatalf("Expected the filter to keep deleted keys")
+	}
+	if len(trie.KeysWithCommonPrefix("the")) != 0 || trie.LongestPrefix("them") != "" {
+		t.Fatalf("Did not expect prefix queries to find keys")
+	}
+}
+
+func benchmarkContainsMisses(b *testing.B, newTrie func() Trie) {
+	words, err := os.ReadFile("words.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	trie := newTrie()
+	trie.InsertAll(strings.NewReader(string(words)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie.Contains("misspeled" + strconv.Itoa(i%100))
+	}
+}
+
+func BenchmarkTrieContainsMisses(b *testing.B) {
+	benchmarkContainsMisses(b, func() Trie { return newTrieNode() })
+}
+
+func BenchmarkFilteredTrieContainsMisses(b *testing.B) {
+	benchmarkContainsMisses(b, func() Trie { return newFilteredTrie(newTrieNode()) })
+}
+
+func BenchmarkMembershipMemory(b *testing.B) {
+	benchmarkMemory(b, func() Trie { return newMembershipFilter() })
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const commitMode = "commit"
+
+var (
+	trailerPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*:\s`)
+	scissorsLine   = "# ------------------------ >8 ------------------------"
+)
+
+type commitLine struct {
+	text string
+	line int
+}
+
+// isTrailerBlock reports whether a paragraph consists only of git trailers such as
+// "Signed-off-by: Name <email>", allowing indented continuation lines.
+func isTrailerBlock(paragraph []commitLine) bool {
+	if len(paragraph) == 0 || !trailerPattern.MatchString(paragraph[0].text) {
+		return false
+	}
+	for _, l := range paragraph[1:] {
+		if !trailerPattern.MatchString(l.text) && !strings.HasPrefix(l.text, " ") && !strings.HasPrefix(l.text, "\t") {
+			return false
+		}
+	}
+	return true
+}
+
+// extractCommitMessage reads a commit message as written by git for a commit-msg hook. Comment
+// lines, quoted lines, the trailer block and anything after the scissors line or the start of a
+// diff (as included by `git commit --verbose`) are skipped.
+func extractCommitMessage(r io.Reader) ([]Segment, error) {
+	var paragraphs [][]commitLine
+	var paragraph []commitLine
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == scissorsLine || strings.HasPrefix(text, "diff --git ") {
+			break
+		}
+		if strings.HasPrefix(text, "#") || strings.HasPrefix(text, ">") {
+			continue
+		}
+		if strings.TrimSpace(text) == "" {
+			if len(paragraph) > 0 {
+				paragraphs = append(paragraphs, paragraph)
+				paragraph = nil
+			}
+			continue
+		}
+		paragraph = append(paragraph, commitLine{text, lineNum})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(paragraph) > 0 {
+		paragraphs = append(paragraphs, paragraph)
+	}
+	// the subject line is never a trailer, even when it looks like one, e.g. "docs: fix typo"
+	if n := len(paragraphs); n > 1 && isTrailerBlock(paragraphs[n-1]) {
+		paragraphs = paragraphs[:n-1]
+	}
+
+	var segments []Segment
+	for _, p := range paragraphs {
+		for _, l := range p {
+			segments = append(segments, Segment{text: l.text, line: l.line})
+		}
+	}
+	return segments, nil
+}
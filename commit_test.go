@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func segmentTexts(segments []Segment) []string {
+	texts := make([]string, len(segments))
+	for i, segment := range segments {
+		texts[i] = segment.text
+	}
+	return texts
+}
+
+func TestExtractCommitMessage(t *testing.T) {
+	message := strings.Join([]string{
+		"Fix the parsr",
+		"",
+		"The parser droped tokens.",
+		"> quoted from the bug report",
+		"# Please enter the commit message for your changes.",
+		"",
+		"Signed-off-by: A Developer <dev@example.com>",
+		"Reviewed-by: Someone Else <else@example.com>",
+		"  continued trailer",
+		"# ------------------------ >8 ------------------------",
+		"diff --git a/main.go b/main.go",
+		"+	mispeled := true",
+	}, "\n")
+	segments, err := extractCommitMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"Fix the parsr", "The parser droped tokens."}
+	actual := segmentTexts(segments)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	if segments[1].line != 3 {
+		t.Fatalf("Expected body on line 3, got %d", segments[1].line)
+	}
+}
+
+func TestExtractCommitMessage_VerboseDiff(t *testing.T) {
+	message := "Add feature\n\ndiff --git a/x b/x\n+mispeled\n"
+	segments, err := extractCommitMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"Add feature"}
+	actual := segmentTexts(segments)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestExtractCommitMessage_SubjectLooksLikeTrailer(t *testing.T) {
+	message := "docs: fix tpyo\n"
+	segments, err := extractCommitMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 {
+		t.Fatalf("Expected subject to be checked, got %v", segmentTexts(segments))
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	csvMode = "csv"
+	tsvMode = "tsv"
+)
+
+// cellWhitespace replaces line breaks and tabs inside cells with spaces, preserving character offsets.
+var cellWhitespace = strings.NewReplacer("\r", " ", "\n", " ", "\t", " ")
+
+// resolveColumns maps column selectors, either header names or 1-based indexes, to column indexes.
+// An empty selection checks every column.
+func resolveColumns(header []string, selectors []string) ([]int, error) {
+	if len(selectors) == 0 {
+		columns := make([]int, len(header))
+		for i := range header {
+			columns[i] = i
+		}
+		return columns, nil
+	}
+	var columns []int
+	for _, selector := range selectors {
+		selector = strings.TrimSpace(selector)
+		found := false
+		for i, name := range header {
+			if strings.TrimSpace(name) == selector {
+				columns = append(columns, i)
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		index, err := strconv.Atoi(selector)
+		if err != nil || index < 1 {
+			return nil, fmt.Errorf("no column named '%s'", selector)
+		}
+		if index > len(header) {
+			return nil, fmt.Errorf("column %d out of range (%d columns)", index, len(header))
+		}
+		columns = append(columns, index-1)
+	}
+	return columns, nil
+}
+
+func columnName(header []string, column int) string {
+	if column < len(header) && strings.TrimSpace(header[column]) != "" {
+		return strings.TrimSpace(header[column])
+	}
+	return strconv.Itoa(column + 1)
+}
+
+// csvExtractor returns an Extractor for delimited files whose first row is a header. Only the
+// selected columns are checked, with errors reported by row, column name and offset in the cell.
+func csvExtractor(delimiter rune, selectors []string) Extractor {
+	return func(r io.Reader) ([]Segment, error) {
+		reader := csv.NewReader(r)
+		reader.Comma = delimiter
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		header, err := reader.Read()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		columns, err := resolveColumns(header, selectors)
+		if err != nil {
+			return nil, err
+		}
+		var segments []Segment
+		for row := 2; ; row++ {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return segments, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, column := range columns {
+				if column >= len(record) {
+					continue
+				}
+				segments = append(segments, Segment{
+					text:     cellWhitespace.Replace(record[column]),
+					line:     len(segments) + 1,
+					location: fmt.Sprintf("Row %d, column %s", row, columnName(header, column)),
+					cell:     true,
+				})
+			}
+		}
+	}
+}
+
+// getDelimitedExtractor returns the Extractor for a csv or tsv mode restricted to the given columns.
+func getDelimitedExtractor(mode string, selectors []string) (Extractor, error) {
+	switch mode {
+	case csvMode:
+		return csvExtractor(',', selectors), nil
+	case tsvMode:
+		return csvExtractor('\t', selectors), nil
+	}
+	return nil, errors.New("columns can only be selected in csv and tsv modes")
+}
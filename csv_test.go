@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveColumns(t *testing.T) {
+	header := []string{"id", "title", "notes"}
+	actual, err := resolveColumns(header, []string{"notes", "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int{2, 1}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	if _, err := resolveColumns(header, []string{"missing"}); err == nil {
+		t.Fatal("Expected error; got no error")
+	}
+	_, err = resolveColumns(header, []string{"4"})
+	if err == nil || err.Error() != "column 4 out of range (3 columns)" {
+		t.Fatalf("Expected out of range error; got %v", err)
+	}
+}
+
+func TestCsvExtractor(t *testing.T) {
+	content := "id,title,notes\n1,Frist,\"multi\nline\"\n2,Second\n"
+	segments, err := csvExtractor(',', []string{"title", "3"})(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"Row 2, column title: Frist",
+		"Row 2, column notes: multi line",
+		"Row 3, column title: Second",
+	}
+	actual := segmentLocations(segments)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestCheckSegments_CellOffset(t *testing.T) {
+	wordList := []string{"the", "quick", "fox"}
+	spellcheck := newSpellcheck(0)
+	spellcheck.InitializeWordList(strings.NewReader(strings.Join(wordList, "\n")))
+	segments, err := csvExtractor('\t', []string{"text"})(strings.NewReader("id\ttext\n1\tThe quick. Brwn fox\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spellingErrors := chanToSlice(spellcheck.CheckSegments(sliceToChan(segments)))
+	if len(spellingErrors) != 1 {
+		t.Fatalf("\nExpected 1 spelling error; got %v\n", spellingErrors)
+	}
+	expected := "Row 2, column text, offset 12: 'Brwn'"
+	if actual := spellingErrors[0].String(); actual != expected {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestGetDelimitedExtractor_WrongMode(t *testing.T) {
+	if _, err := getDelimitedExtractor("docx", []string{"1"}); err == nil {
+		t.Fatal("Expected error; got no error")
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DawgNode is a state of a minimal directed acyclic word graph: a trie in which equivalent
+// subtrees are shared, so common suffixes such as "-ing" or "-ness" are stored once. It is built
+// in one pass from sorted keys and is read-only afterwards.
+type DawgNode struct {
+	isKey bool
+	edges []dawgEdge
+	// id identifies the node in the register of minimized nodes while building
+	id int
+	// count is the number of keys reachable from this node, including its own
+	count int
+}
+
+// dawgEdge leads to a child node. Edges of a node are sorted by rune.
+type dawgEdge struct {
+	c    rune
+	node *DawgNode
+}
+
+func newDawgNode() *DawgNode {
+	return &DawgNode{}
+}
+
+// dawgBuilder minimizes the graph incrementally as sorted keys are added, following
+// Daciuk et al., "Incremental Construction of Minimal Acyclic Finite-State Automata".
+type dawgBuilder struct {
+	root     *DawgNode
+	register map[string]*DawgNode
+}
+
+// signature identifies a node by its finality and outgoing edges, which are already minimized.
+func (n *DawgNode) signature() string {
+	var b strings.Builder
+	if n.isKey {
+		b.WriteByte('1')
+	} else {
+		b.WriteByte('0')
+	}
+	for _, edge := range n.edges {
+		b.WriteRune(edge.c)
+		b.WriteString(strconv.Itoa(edge.node.id))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// replaceOrRegister minimizes the most recently added branch below node, replacing each node
+// with an equivalent registered node where one exists.
+func (builder *dawgBuilder) replaceOrRegister(node *DawgNode) {
+	last := &node.edges[len(node.edges)-1]
+	child := last.node
+	if len(child.edges) > 0 {
+		builder.replaceOrRegister(child)
+	}
+	sig := child.signature()
+	if equivalent, ok := builder.register[sig]; ok {
+		last.node = equivalent
+	} else {
+		child.id = len(builder.register) + 1
+		child.countKeys()
+		builder.register[sig] = child
+	}
+}
+
+// countKeys sets the node's count from its children's, which must already be counted.
+func (n *DawgNode) countKeys() {
+	n.count = 0
+	if n.isKey {
+		n.count = 1
+	}
+	for _, edge := range n.edges {
+		n.count += edge.node.count
+	}
+}
+
+// add appends a key, which must sort after every key added before it.
+func (builder *dawgBuilder) add(key string) {
+	currentNode := builder.root
+	chars := []rune(key)
+	// follow the prefix shared with the previous key, which is always the last edge
+	i := 0
+	for i < len(chars) && len(currentNode.edges) > 0 && currentNode.edges[len(currentNode.edges)-1].c == chars[i] {
+		currentNode = currentNode.edges[len(currentNode.edges)-1].node
+		i++
+	}
+	if len(currentNode.edges) > 0 {
+		builder.replaceOrRegister(currentNode)
+	}
+	for ; i < len(chars); i++ {
+		child := newDawgNode()
+		currentNode.edges = append(currentNode.edges, dawgEdge{chars[i], child})
+		currentNode = child
+	}
+	currentNode.isKey = true
+}
+
+func (builder *dawgBuilder) finish() *DawgNode {
+	if len(builder.root.edges) > 0 {
+		builder.replaceOrRegister(builder.root)
+	}
+	builder.root.countKeys()
+	builder.register = nil
+	return builder.root
+}
+
+// buildDawg builds a minimal DAWG from keys, which need not be sorted or unique.
+func buildDawg(keys []string) *DawgNode {
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+	builder := dawgBuilder{
+		root:     newDawgNode(),
+		register: make(map[string]*DawgNode),
+	}
+	for _, key := range keys {
+		builder.add(key)
+	}
+	return builder.finish()
+}
+
+// Insert is not supported by the read-only DAWG and returns false; build it with InsertAll.
+func (t *DawgNode) Insert(key string) bool {
+	return false
+}
+
+// InsertAll rebuilds the graph from its current keys and the newline-separated keys read from r.
+func (t *DawgNode) InsertAll(r io.Reader) LoadReport {
+	words, report := readWords(r)
+	*t = *buildDawg(append(t.Enumerate(), words...))
+	return report
+}
+
+// Delete is not supported by the read-only DAWG and returns false.
+func (t *DawgNode) Delete(key string) bool {
+	return false
+}
+
+// DeleteAll is not supported by the read-only DAWG, which it leaves unchanged.
+func (t *DawgNode) DeleteAll(r io.Reader) LoadReport {
+	return LoadReport{err: errReadOnly}
+}
+
+func (t *DawgNode) child(c rune) (*DawgNode, bool) {
+	i := sort.Search(len(t.edges), func(i int) bool {
+		return t.edges[i].c >= c
+	})
+	if i < len(t.edges) && t.edges[i].c == c {
+		return t.edges[i].node, true
+	}
+	return nil, false
+}
+
+func (t *DawgNode) Contains(key string) bool {
+	currentNode := t
+	for _, c := range key {
+		child, hasChild := currentNode.child(c)
+		if !hasChild {
+			return false
+		}
+		currentNode = child
+	}
+	return currentNode.isKey
+}
+
+// walk follows s as far as the graph allows, returning the matched prefix and the node it leads to.
+func (t *DawgNode) walk(s string) (string, *DawgNode) {
+	currentNode := t
+	for i, c := range s {
+		child, hasChild := currentNode.child(c)
+		if !hasChild {
+			return s[:i], currentNode
+		}
+		currentNode = child
+	}
+	return s, currentNode
+}
+
+func (t *DawgNode) LongestPrefix(s string) string {
+	prefix, _ := t.walk(s)
+	return prefix
+}
+
+func (t *DawgNode) KeysWithCommonPrefix(s string) []string {
+	prefix, node := t.walk(s)
+	if len(prefix) == 0 {
+		return []string{}
+	}
+	keys := node.Enumerate()
+	slices.Sort(keys)
+	for i := 0; i < len(keys); i++ {
+		keys[i] = prefix + keys[i]
+	}
+	return keys
+}
+
+func (t *DawgNode) Walk(prefix string, visit func(key string) bool) {
+	matched, node := t.walk(prefix)
+	if len(matched) < len(prefix) {
+		return
+	}
+	node.walkKeys([]byte(prefix), visit)
+}
+
+func (t *DawgNode) walkKeys(key []byte, visit func(key string) bool) bool {
+	if t.isKey && !visit(string(key)) {
+		return false
+	}
+	for _, edge := range t.edges {
+		if !edge.node.walkKeys(utf8.AppendRune(key, edge.c), visit) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *DawgNode) CountWithPrefix(prefix string) int {
+	matched, node := t.walk(prefix)
+	if len(matched) < len(prefix) {
+		return 0
+	}
+	return node.count
+}
+
+// Size returns the number of keys in the graph.
+func (t *DawgNode) Size() int {
+	return t.count
+}
+
+type dawgPath struct {
+	prefix string
+	node   *DawgNode
+}
+
+func (t *DawgNode) Enumerate() []string {
+	var neighborStack = make(stack[dawgPath], 0)
+	var enumeration []string
+	neighborStack.push(dawgPath{"", t})
+
+	for neighborStack.size() > 0 {
+		currentPath := neighborStack.pop()
+		if currentPath.node.isKey {
+			enumeration = append(enumeration, currentPath.prefix)
+		}
+		for _, edge := range currentPath.node.edges {
+			neighborStack.push(dawgPath{
+				prefix: currentPath.prefix + string(edge.c),
+				node:   edge.node,
+			})
+		}
+	}
+	return enumeration
+}
+
+func (t *DawgNode) String() string {
+	return strings.Join(t.Enumerate(), "\n")
+}
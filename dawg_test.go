@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// countDawgNodes counts the distinct nodes reachable from root.
+func countDawgNodes(root *DawgNode) int {
+	seen := map[*DawgNode]bool{root: true}
+	nodeStack := stack[*DawgNode]{root}
+	for nodeStack.size() > 0 {
+		node := nodeStack.pop()
+		for _, edge := range node.edges {
+			if !seen[edge.node] {
+				seen[edge.node] = true
+				nodeStack.push(edge.node)
+			}
+		}
+	}
+	return len(seen)
+}
+
+func TestDawgSharesSuffixes(t *testing.T) {
+	var trie Trie = newDawgNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"walking", "talking", "walked", "talked"}, "\n")))
+	for _, key := range []string{"walking", "talking", "walked", "talked"} {
+		if !trie.Contains(key) {
+			t.Fatalf("Expected trie to contain %v\n", key)
+		}
+	}
+	if trie.Contains("walk") || trie.Contains("talkings") {
+		t.Fatalf("Did not expect trie to contain prefixes or extensions of keys")
+	}
+	// both stems lead to the same "alk" states, and every key ends in the same final state:
+	// root, after "w"/"t", "a", "l", "k", "i", "n", "e", and final
+	expected := 9
+	if actual := countDawgNodes(trie.(*DawgNode)); actual != expected {
+		t.Fatalf("Expected %d nodes; got %d", expected, actual)
+	}
+}
+
+func TestDawgReadOnly(t *testing.T) {
+	trie := newDawgNode()
+	trie.InsertAll(strings.NewReader("abc\nabd"))
+	if trie.Insert("xyz") || trie.Contains("xyz") {
+		t.Fatalf("Expected Insert to be unsupported")
+	}
+	if trie.Delete("abc") || !trie.Contains("abc") {
+		t.Fatalf("Expected Delete to be unsupported")
+	}
+	trie.InsertAll(strings.NewReader("abe"))
+	expected := []string{"abc", "abd", "abe"}
+	actual := trie.KeysWithCommonPrefix("ab")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestDawgMatchesTrieNode(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	letters := []rune("abcdé")
+	randomWord := func() string {
+		word := make([]rune, 1+r.Intn(6))
+		for i := range word {
+			word[i] = letters[r.Intn(len(letters))]
+		}
+		return string(word)
+	}
+	var words []string
+	for i := 0; i < 500; i++ {
+		words = append(words, randomWord())
+	}
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader(strings.Join(words, "\n")))
+	dawg := newDawgNode()
+	dawg.InsertAll(strings.NewReader(strings.Join(words, "\n")))
+
+	expected := trie.Enumerate()
+	actual := dawg.Enumerate()
+	slices.Sort(expected)
+	slices.Sort(actual)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	for i := 0; i < 500; i++ {
+		word := randomWord()
+		if trie.Contains(word) != dawg.Contains(word) {
+			t.Fatalf("Contains(%q) differs", word)
+		}
+		if trie.LongestPrefix(word) != dawg.LongestPrefix(word) {
+			t.Fatalf("LongestPrefix(%q): expected %q, got %q", word, trie.LongestPrefix(word), dawg.LongestPrefix(word))
+		}
+		if !reflect.DeepEqual(trie.KeysWithCommonPrefix(word), dawg.KeysWithCommonPrefix(word)) {
+			t.Fatalf("KeysWithCommonPrefix(%q) differs", word)
+		}
+	}
+}
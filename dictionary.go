@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"slices"
+)
+
+// A compiled dictionary is a DAWG serialized as flat little-endian arrays, so it can be loaded
+// without parsing a word list or rebuilding the graph:
+//
+//	header  magic "GSCD", version, node count, edge count (uint32 each)
+//	nodes   first edge index, edge count<<1 | isKey, number of keys below and at the node
+//	        (uint32 each), root first
+//	edges   rune, target node index (uint32 each), sorted by rune within a node
+//	trailer CRC-32 (IEEE) of everything before it
+//
+// Nodes are stored in topological order, so every edge leads to a node with a greater index.
+const (
+	dictionaryMagic   = "GSCD"
+	dictionaryVersion = 2
+	headerSize        = 16
+	nodeSize          = 12
+	edgeSize          = 8
+	trailerSize       = 4
+)
+
+var errInvalidDictionary = errors.New("invalid compiled dictionary")
+
+// isCompiledDictionary reports whether r starts with a compiled dictionary header, without consuming it.
+func isCompiledDictionary(r *bufio.Reader) bool {
+	magic, err := r.Peek(len(dictionaryMagic))
+	return err == nil && string(magic) == dictionaryMagic
+}
+
+// topologicalOrder lists the nodes reachable from root so that each node precedes its children.
+func topologicalOrder(root *DawgNode) []*DawgNode {
+	type visit struct {
+		node     *DawgNode
+		expanded bool
+	}
+	var postorder []*DawgNode
+	visited := make(map[*DawgNode]bool)
+	visitStack := stack[visit]{{root, false}}
+	for visitStack.size() > 0 {
+		current := visitStack.pop()
+		if current.expanded {
+			postorder = append(postorder, current.node)
+			continue
+		}
+		if visited[current.node] {
+			continue
+		}
+		visited[current.node] = true
+		visitStack.push(visit{current.node, true})
+		for _, edge := range current.node.edges {
+			if !visited[edge.node] {
+				visitStack.push(visit{edge.node, false})
+			}
+		}
+	}
+	slices.Reverse(postorder)
+	return postorder
+}
+
+func writeUint32s(buf *bytes.Buffer, values ...uint32) {
+	var b [4]byte
+	for _, v := range values {
+		binary.LittleEndian.PutUint32(b[:], v)
+		buf.Write(b[:])
+	}
+}
+
+// writeCompiledDictionary serializes the graph under root in the compiled dictionary format.
+func writeCompiledDictionary(w io.Writer, root *DawgNode) error {
+	nodes := topologicalOrder(root)
+	indexes := make(map[*DawgNode]uint32, len(nodes))
+	edgeCount := 0
+	for i, node := range nodes {
+		indexes[node] = uint32(i)
+		edgeCount += len(node.edges)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, headerSize+len(nodes)*nodeSize+edgeCount*edgeSize+trailerSize))
+	buf.WriteString(dictionaryMagic)
+	writeUint32s(buf, dictionaryVersion, uint32(len(nodes)), uint32(edgeCount))
+	firstEdge := uint32(0)
+	for _, node := range nodes {
+		info := uint32(len(node.edges)) << 1
+		if node.isKey {
+			info |= 1
+		}
+		writeUint32s(buf, firstEdge, info, uint32(node.count))
+		firstEdge += uint32(len(node.edges))
+	}
+	for _, node := range nodes {
+		for _, edge := range node.edges {
+			writeUint32s(buf, uint32(edge.c), indexes[edge.node])
+		}
+	}
+	writeUint32s(buf, crc32.ChecksumIEEE(buf.Bytes()))
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// compiledHeader validates the header and size of a compiled dictionary and returns its node and edge counts.
+func compiledHeader(data []byte) (uint32, uint32, error) {
+	if len(data) < headerSize+trailerSize || string(data[:4]) != dictionaryMagic {
+		return 0, 0, errInvalidDictionary
+	}
+	version := binary.LittleEndian.Uint32(data[4:])
+	if version != dictionaryVersion {
+		return 0, 0, fmt.Errorf("unsupported compiled dictionary version %d", version)
+	}
+	nodeCount := binary.LittleEndian.Uint32(data[8:])
+	edgeCount := binary.LittleEndian.Uint32(data[12:])
+	if nodeCount == 0 || uint64(len(data)) != headerSize+uint64(nodeCount)*nodeSize+uint64(edgeCount)*edgeSize+trailerSize {
+		return 0, 0, errInvalidDictionary
+	}
+	return nodeCount, edgeCount, nil
+}
+
+func verifyChecksum(data []byte) error {
+	n := len(data) - trailerSize
+	if crc32.ChecksumIEEE(data[:n]) != binary.LittleEndian.Uint32(data[n:]) {
+		return fmt.Errorf("%w: checksum mismatch", errInvalidDictionary)
+	}
+	return nil
+}
+
+// readCompiledDictionary loads a compiled dictionary into a DAWG.
+func readCompiledDictionary(r io.Reader) (*DawgNode, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	nodeCount, edgeCount, err := compiledHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksum(data); err != nil {
+		return nil, err
+	}
+	nodes := make([]DawgNode, nodeCount)
+	edges := make([]dawgEdge, edgeCount)
+	nodeData := data[headerSize:]
+	edgeData := nodeData[int(nodeCount)*nodeSize:]
+	for i := uint32(0); i < edgeCount; i++ {
+		target := binary.LittleEndian.Uint32(edgeData[int(i)*edgeSize+4:])
+		if target >= nodeCount {
+			return nil, errInvalidDictionary
+		}
+		edges[i] = dawgEdge{
+			c:    rune(binary.LittleEndian.Uint32(edgeData[int(i)*edgeSize:])),
+			node: &nodes[target],
+		}
+	}
+	for i := uint32(0); i < nodeCount; i++ {
+		firstEdge := binary.LittleEndian.Uint32(nodeData[int(i)*nodeSize:])
+		info := binary.LittleEndian.Uint32(nodeData[int(i)*nodeSize+4:])
+		count := info >> 1
+		if uint64(firstEdge)+uint64(count) > uint64(edgeCount) {
+			return nil, errInvalidDictionary
+		}
+		nodes[i].isKey = info&1 == 1
+		nodes[i].count = int(binary.LittleEndian.Uint32(nodeData[int(i)*nodeSize+8:]))
+		nodes[i].edges = edges[firstEdge : firstEdge+count : firstEdge+count]
+		// edges must lead forward so the graph can't contain cycles
+		for j := firstEdge; j < firstEdge+count; j++ {
+			if binary.LittleEndian.Uint32(edgeData[int(j)*edgeSize+4:]) <= i {
+				return nil, errInvalidDictionary
+			}
+		}
+	}
+	// children follow their parents, so counting backwards checks each node's children first
+	for i := len(nodes) - 1; i >= 0; i-- {
+		stored := nodes[i].count
+		if nodes[i].countKeys(); nodes[i].count != stored {
+			return nil, errInvalidDictionary
+		}
+	}
+	return &nodes[0], nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func compileWords(t *testing.T, words []string) []byte {
+	dawg := newDawgNode()
+	dawg.InsertAll(strings.NewReader(strings.Join(words, "\n")))
+	var buf bytes.Buffer
+	if err := writeCompiledDictionary(&buf, dawg); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCompiledDictionaryRoundTrip(t *testing.T) {
+	words := []string{"walking", "talking", "walked", "talked", "naïve", "a"}
+	data := compileWords(t, words)
+	r := bufio.NewReader(bytes.NewReader(data))
+	if !isCompiledDictionary(r) {
+		t.Fatalf("Expected compiled dictionary header")
+	}
+	dictionary, err := readCompiledDictionary(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := dictionary.Enumerate()
+	slices.Sort(actual)
+	slices.Sort(words)
+	if !reflect.DeepEqual(actual, words) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", words, actual)
+	}
+	if !dictionary.Contains("naïve") || dictionary.Contains("walk") {
+		t.Fatalf("Unexpected lookups in loaded dictionary")
+	}
+}
+
+func TestCompiledDictionary_TextIsNotCompiled(t *testing.T) {
+	if isCompiledDictionary(bufio.NewReader(strings.NewReader("abc\ndef"))) {
+		t.Fatalf("Did not expect a word list to be detected as compiled")
+	}
+}
+
+func TestCompiledDictionary_Corrupt(t *testing.T) {
+	data := compileWords(t, []string{"abc", "abd"})
+	corrupt := bytes.Clone(data)
+	corrupt[headerSize] ^= 0xff
+	if _, err := readCompiledDictionary(bytes.NewReader(corrupt)); !errors.Is(err, errInvalidDictionary) {
+		t.Fatalf("Expected checksum error, got %v", err)
+	}
+	if _, err := readCompiledDictionary(bytes.NewReader(data[:len(data)-1])); err == nil {
+		t.Fatal("Expected error for truncated dictionary; got no error")
+	}
+	newer := bytes.Clone(data)
+	newer[4] = dictionaryVersion + 1
+	if _, err := readCompiledDictionary(bytes.NewReader(newer)); err == nil {
+		t.Fatal("Expected error for unsupported version; got no error")
+	}
+}
+
+func TestCompiledDictionary_WrongCount(t *testing.T) {
+	data := compileWords(t, []string{"abc", "abd"})
+	// claim an extra key below the root and keep the checksum valid
+	binary.LittleEndian.PutUint32(data[headerSize+8:], 3)
+	n := len(data) - trailerSize
+	binary.LittleEndian.PutUint32(data[n:], crc32.ChecksumIEEE(data[:n]))
+	if _, err := readCompiledDictionary(bytes.NewReader(data)); !errors.Is(err, errInvalidDictionary) {
+		t.Fatalf("Expected %v, got %v", errInvalidDictionary, err)
+	}
+}
+
+func TestCompileCommand(t *testing.T) {
+	dir := t.TempDir()
+	wordFile := dir + "/words.txt"
+	if err := os.WriteFile(wordFile, []byte("abc\ndef\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := dir + "/words.dict"
+	if err := compileCommand([]string{wordFile, output}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	dictionary, err := readCompiledDictionary(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dictionary.Contains("abc") || !dictionary.Contains("def") {
+		t.Fatalf("Expected compiled dictionary to contain the word list, got %v", dictionary.Enumerate())
+	}
+}
+
+func BenchmarkLoadWordList(b *testing.B) {
+	words, err := os.ReadFile("words.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie := newTrieNode()
+		trie.InsertAll(bytes.NewReader(words))
+	}
+}
+
+func BenchmarkLoadCompiledDictionary(b *testing.B) {
+	words, err := os.ReadFile("words.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	dawg := newDawgNode()
+	dawg.InsertAll(bytes.NewReader(words))
+	var buf bytes.Buffer
+	if err := writeCompiledDictionary(&buf, dawg); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := readCompiledDictionary(bytes.NewReader(buf.Bytes())); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
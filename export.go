@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// exportFormats write the subtree of a trie under a prefix, down to a depth limit.
+var exportFormats = map[string]func(t *TrieNode, w io.Writer, prefix string, maxDepth int) error{
+	"dot":  (*TrieNode).WriteDOT,
+	"json": (*TrieNode).WriteJSON,
+}
+
+// subtree returns the node prefix leads to, or an error if no key starts with prefix.
+func (t *ValueTrie[V]) subtree(prefix string) (*ValueTrie[V], error) {
+	node := t.find(prefix)
+	if node == nil || node.count == 0 {
+		return nil, fmt.Errorf("no keys start with %q", prefix)
+	}
+	return node, nil
+}
+
+// sortedChildren returns the runes of the node's children in lexicographic order.
+func (t *ValueTrie[V]) sortedChildren() []rune {
+	chars := make([]rune, 0, len(t.children))
+	for c := range t.children {
+		chars = append(chars, c)
+	}
+	slices.Sort(chars)
+	return chars
+}
+
+// WriteDOT renders the subtree under prefix as a Graphviz graph, with edges labelled by rune and
+// keys drawn as double circles. With maxDepth above zero, only that many levels below prefix are
+// drawn, and each node cut off is followed by the number of keys below it.
+func (t *ValueTrie[V]) WriteDOT(w io.Writer, prefix string, maxDepth int) error {
+	root, err := t.subtree(prefix)
+	if err != nil {
+		return err
+	}
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "digraph trie {\n")
+	fmt.Fprintf(b, "\tnode [shape=circle, label=\"\"];\n")
+	fmt.Fprintf(b, "\tn0 [xlabel=%s];\n", strconv.Quote(prefix))
+	// nodes are numbered in the order they are drawn
+	nextID := 1
+	var visit func(node *ValueTrie[V], nodeID int, depth int)
+	visit = func(node *ValueTrie[V], nodeID int, depth int) {
+		if node.isKey {
+			fmt.Fprintf(b, "\tn%d [shape=doublecircle];\n", nodeID)
+		}
+		if maxDepth > 0 && depth == maxDepth && len(node.children) > 0 {
+			fmt.Fprintf(b, "\tn%d [shape=plaintext, label=\"+%d\"];\n", nextID, node.count-boolToInt(node.isKey))
+			fmt.Fprintf(b, "\tn%d -> n%d [style=dashed];\n", nodeID, nextID)
+			nextID++
+			return
+		}
+		for _, c := range node.sortedChildren() {
+			childID := nextID
+			nextID++
+			fmt.Fprintf(b, "\tn%d -> n%d [label=%s];\n", nodeID, childID, strconv.Quote(string(c)))
+			visit(node.children[c], childID, depth+1)
+		}
+	}
+	visit(root, 0, 0)
+	fmt.Fprintf(b, "}\n")
+	return b.Flush()
+}
+
+// jsonNode is a node of a trie exported as nested JSON. The root holds the prefix and each other
+// node the rune leading to it. count is the number of keys in the subtree, including those below
+// a depth limit, whose children are left out.
+type jsonNode struct {
+	Prefix   *string    `json:"prefix,omitempty"`
+	Rune     string     `json:"rune,omitempty"`
+	Key      bool       `json:"key"`
+	Count    int        `json:"count"`
+	Children []jsonNode `json:"children,omitempty"`
+}
+
+// WriteJSON renders the subtree under prefix as nested JSON objects with children sorted by rune.
+// With maxDepth above zero, only that many levels below prefix are included.
+func (t *ValueTrie[V]) WriteJSON(w io.Writer, prefix string, maxDepth int) error {
+	root, err := t.subtree(prefix)
+	if err != nil {
+		return err
+	}
+	var build func(node *ValueTrie[V], depth int) jsonNode
+	build = func(node *ValueTrie[V], depth int) jsonNode {
+		exported := jsonNode{Key: node.isKey, Count: node.count}
+		if maxDepth > 0 && depth == maxDepth {
+			return exported
+		}
+		for _, c := range node.sortedChildren() {
+			child := build(node.children[c], depth+1)
+			child.Rune = string(c)
+			exported.Children = append(exported.Children, child)
+		}
+		return exported
+	}
+	exported := build(root, 0)
+	exported.Prefix = &prefix
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(exported)
+}
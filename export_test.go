@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func exportWords(words []string) *TrieNode {
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader(strings.Join(words, "\n")))
+	return trie
+}
+
+func TestWriteDOT(t *testing.T) {
+	trie := exportWords([]string{"the", "them", "then", "zebra"})
+	var buf bytes.Buffer
+	if err := trie.WriteDOT(&buf, "the", 0); err != nil {
+		t.Fatal(err)
+	}
+	expected := `digraph trie {
+	node [shape=circle, label=""];
+	n0 [xlabel="the"];
+	n0 [shape=doublecircle];
+	n0 -> n1 [label="m"];
+	n1 [shape=doublecircle];
+	n0 -> n2 [label="n"];
+	n2 [shape=doublecircle];
+}
+`
+	if actual := buf.String(); actual != expected {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestWriteDOT_Depth(t *testing.T) {
+	trie := exportWords([]string{"the", "them", "theme", "zebra"})
+	var buf bytes.Buffer
+	if err := trie.WriteDOT(&buf, "", 2); err != nil {
+		t.Fatal(err)
+	}
+	actual := buf.String()
+	// "th" is cut off with the 3 keys below it; "ze" with the one below it
+	for _, expected := range []string{`label="+3"`, `label="+1"`} {
+		if !strings.Contains(actual, expected) {
+			t.Fatalf("Expected %s in\n%v", expected, actual)
+		}
+	}
+	// t, h, z and e, then the two cut-off markers
+	if strings.Count(actual, "->") != 6 {
+		t.Fatalf("Did not expect nodes below depth 2 in\n%v", actual)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	trie := exportWords([]string{"the", "them", "theme", "then"})
+	var buf bytes.Buffer
+	if err := trie.WriteJSON(&buf, "the", 1); err != nil {
+		t.Fatal(err)
+	}
+	var actual jsonNode
+	if err := json.Unmarshal(buf.Bytes(), &actual); err != nil {
+		t.Fatal(err)
+	}
+	prefix := "the"
+	expected := jsonNode{
+		Prefix: &prefix,
+		Key:    true,
+		Count:  4,
+		Children: []jsonNode{
+			{Rune: "m", Key: true, Count: 2},
+			{Rune: "n", Key: true, Count: 1},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%+v\nActual:\t\t%+v\n", expected, actual)
+	}
+}
+
+func TestExport_MissingPrefix(t *testing.T) {
+	trie := exportWords([]string{"the"})
+	for format, export := range exportFormats {
+		if err := export(trie, &bytes.Buffer{}, "x", 0); err == nil {
+			t.Fatalf("%s: expected error; got no error", format)
+		}
+	}
+}
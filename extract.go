@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Extractor converts the contents of a target file into segments of plain text to spellcheck.
+type Extractor func(r io.Reader) ([]Segment, error)
+
+const textMode = "text"
+
+var extractors = map[string]Extractor{
+	"docx":     extractDocx,
+	"odt":      extractOdt,
+	"epub":     extractEpub,
+	commitMode: extractCommitMessage,
+	mailMode:   extractMail,
+	csvMode:    csvExtractor(',', nil),
+	tsvMode:    csvExtractor('\t', nil),
+	manMode:    extractRoff,
+}
+
+// extensionModes maps lower-case target file extensions to the mode used to read them.
+var extensionModes = map[string]string{
+	".docx": "docx",
+	".odt":  "odt",
+	".epub": "epub",
+	".eml":  mailMode,
+	".mbox": mailMode,
+	".csv":  csvMode,
+	".tsv":  tsvMode,
+	".man":  manMode,
+	".roff": manMode,
+}
+
+func modeNames() []string {
+	names := []string{textMode}
+	for name := range extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// detectMode picks an input mode from the target's file name or extension, falling back to plain text.
+func detectMode(targetPath string) string {
+	switch filepath.Base(targetPath) {
+	case "COMMIT_EDITMSG", "MERGE_MSG", "SQUASH_MSG", "TAG_EDITMSG":
+		return commitMode
+	}
+	ext := strings.ToLower(filepath.Ext(targetPath))
+	if mode, ok := extensionModes[ext]; ok {
+		return mode
+	}
+	// man page sections, e.g. ls.1 or printf.3
+	if len(ext) == 2 && ext[1] >= '1' && ext[1] <= '9' {
+		return manMode
+	}
+	return textMode
+}
+
+func getExtractor(mode string) (Extractor, error) {
+	extractor, ok := extractors[mode]
+	if !ok {
+		return nil, fmt.Errorf("unknown mode '%s'; expected one of %v", mode, modeNames())
+	}
+	return extractor, nil
+}
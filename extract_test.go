@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDetectMode(t *testing.T) {
+	cases := map[string]string{
+		"notes.txt":           textMode,
+		"-":                   textMode,
+		"report.DOCX":         "docx",
+		"letter.odt":          "odt",
+		"novel.epub":          "epub",
+		"archive.tar.odt":     "odt",
+		".git/COMMIT_EDITMSG": commitMode,
+		"ls.1":                manMode,
+		"notes.10":            textMode,
+	}
+	for path, expected := range cases {
+		actual := detectMode(path)
+		if actual != expected {
+			t.Fatalf("detectMode(%q): expected %v, got %v", path, expected, actual)
+		}
+	}
+}
+
+func TestGetExtractor_Unknown(t *testing.T) {
+	_, err := getExtractor("pptx")
+	if err == nil {
+		t.Fatal("Expected error; got no error")
+	}
+}
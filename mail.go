@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+)
+
+const mailMode = "mail"
+
+// splitMbox splits an mbox file into its messages, un-escaping ">From " lines. Input that
+// doesn't start with a "From " separator line is treated as a single message.
+func splitMbox(r io.Reader) ([][]byte, error) {
+	var messages [][]byte
+	var current *bytes.Buffer
+	flush := func() {
+		if current != nil {
+			messages = append(messages, current.Bytes())
+		}
+	}
+	scanner := bufio.NewScanner(r)
+	isMbox := false
+	for i := 0; scanner.Scan(); i++ {
+		line := scanner.Text()
+		if i == 0 {
+			isMbox = strings.HasPrefix(line, "From ")
+		}
+		if isMbox && strings.HasPrefix(line, "From ") {
+			flush()
+			current = &bytes.Buffer{}
+			continue
+		}
+		if current == nil {
+			current = &bytes.Buffer{}
+		}
+		if isMbox && strings.HasPrefix(line, ">") && strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			line = line[1:]
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return messages, nil
+}
+
+func decodeTransferEncoding(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	}
+	return r
+}
+
+// plainTextParts returns the decoded text/plain parts of a message body, descending into multipart bodies.
+func plainTextParts(contentType string, encoding string, body io.Reader) ([]string, error) {
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		var texts []string
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return texts, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(part.Header.Get("Content-Disposition"), "attachment") {
+				continue
+			}
+			partTexts, err := plainTextParts(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return nil, err
+			}
+			texts = append(texts, partTexts...)
+		}
+	}
+	if mediaType != "text/plain" {
+		return nil, nil
+	}
+	text, err := io.ReadAll(decodeTransferEncoding(body, encoding))
+	if err != nil {
+		return nil, err
+	}
+	return []string{string(text)}, nil
+}
+
+// extractMail reads an RFC 5322 message or an mbox file of messages, checking each message's
+// Subject and text/plain body parts. Other headers and quoted reply lines are skipped.
+func extractMail(r io.Reader) ([]Segment, error) {
+	messages, err := splitMbox(r)
+	if err != nil {
+		return nil, err
+	}
+	var segments []Segment
+	decoder := new(mime.WordDecoder)
+	for m, raw := range messages {
+		msg, err := mail.ReadMessage(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", m+1, err)
+		}
+		subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+		if err != nil {
+			subject = msg.Header.Get("Subject")
+		}
+		if subject != "" {
+			segments = append(segments, Segment{
+				text:     subject,
+				line:     len(segments) + 1,
+				location: fmt.Sprintf("Message %d, subject", m+1),
+			})
+		}
+		texts, err := plainTextParts(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", m+1, err)
+		}
+		bodyLine := 0
+		for _, text := range texts {
+			for _, line := range strings.Split(text, "\n") {
+				bodyLine++
+				line = strings.TrimRight(line, "\r")
+				if strings.HasPrefix(strings.TrimSpace(line), ">") {
+					continue
+				}
+				segments = append(segments, Segment{
+					text:     line,
+					line:     len(segments) + 1,
+					location: fmt.Sprintf("Message %d, body line %d", m+1, bodyLine),
+				})
+			}
+		}
+	}
+	return segments, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractMail_Multipart(t *testing.T) {
+	message := strings.Join([]string{
+		"From: Sender <sender@example.com>",
+		"To: Receiver <receiver@example.com>",
+		"Subject: =?UTF-8?Q?Meeting_agnda?=",
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=\"b1\"",
+		"",
+		"--b1",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"See you tomorow at the meet=",
+		"ing.",
+		"> Quoted mispeling",
+		"--b1",
+		"Content-Type: text/html",
+		"",
+		"<p>Ignored hmtl</p>",
+		"--b1--",
+		"",
+	}, "\r\n")
+	segments, err := extractMail(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"Message 1, subject: Meeting agnda",
+		"Message 1, body line 1: See you tomorow at the meeting.",
+	}
+	var actual []string
+	for _, s := range segmentLocations(segments) {
+		if !strings.HasSuffix(s, ": ") {
+			actual = append(actual, s)
+		}
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestExtractMail_Mbox(t *testing.T) {
+	mbox := strings.Join([]string{
+		"From sender@example.com Mon Jan  1 00:00:00 2024",
+		"Subject: First",
+		"",
+		">From the start.",
+		"",
+		"From other@example.com Mon Jan  1 00:00:00 2024",
+		"Subject: Second",
+		"Content-Transfer-Encoding: base64",
+		"",
+		"U2Vjb25k",
+		"IGJvZHk=",
+		"",
+	}, "\n")
+	segments, err := extractMail(strings.NewReader(mbox))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"Message 1, subject: First",
+		"Message 1, body line 1: From the start.",
+		"Message 2, subject: Second",
+		"Message 2, body line 1: Second body",
+	}
+	var actual []string
+	for _, s := range segmentLocations(segments) {
+		if !strings.HasSuffix(s, ": ") {
+			actual = append(actual, s)
+		}
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
@@ -9,13 +9,40 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"strings"
 )
 
+// subcommands are run instead of a spellcheck when named by the first argument.
+var subcommands = map[string]func(args []string) error{
+	"compile":    compileCommand,
+	"anagram":    anagramCommand,
+	"union":      setCommand(Union),
+	"intersect":  setCommand(Intersect),
+	"difference": setCommand(Difference),
+	"dict":       dictCommand,
+	"complete":   completeCommand,
+}
+
+// dictCommands are the subcommands of "dict", which inspect a dictionary.
+var dictCommands = map[string]func(args []string) error{
+	"stats":  dictStatsCommand,
+	"export": dictExportCommand,
+}
+
 func usage() {
 	fmt.Printf("\nUsage:\n\tgospellcheck [OPTIONS] WORDLIST TARGET\n")
-	fmt.Printf("\nWORDLIST\n\tnewline-delimited file of words to populate the spellcheck dictionary\n")
+	fmt.Printf("\tgospellcheck compile WORDLIST OUTPUT\n")
+	fmt.Printf("\tgospellcheck anagram [-subset] WORDLIST LETTERS\n")
+	fmt.Printf("\tgospellcheck union|intersect|difference WORDLIST WORDLIST\n")
+	fmt.Printf("\tgospellcheck dict stats [-d DICTIONARY] WORDLIST\n")
+	fmt.Printf("\tgospellcheck dict export [-format dot|json] [-prefix PREFIX] [-depth N] WORDLIST\n")
+	fmt.Printf("\tgospellcheck complete [-k DISTANCE] [-n LIMIT] WORDLIST INPUT\n")
+	fmt.Printf("\nWORDLIST\n\tnewline-delimited file of words to populate the spellcheck dictionary, or a compiled dictionary\n")
 	fmt.Printf("\nTARGET\n\tfile to spellcheck, or '-' to read from stdin\n")
 	fmt.Printf("\nOPTIONS\n\t-s\tnumber of words to suggest for each misspelling\n")
+	fmt.Printf("\t-m\tinput mode, one of %v; detected from the TARGET extension by default\n", modeNames())
+	fmt.Printf("\t-d\tdictionary representation: trie (default), radix, dawg for a smaller read-only dictionary, sync for a trie safe for concurrent updates, persistent for versioned snapshots, bloom for a trie behind a Bloom filter, or membership for a Bloom filter alone, which uses far less memory but accepts about 1%% of misspellings and cannot suggest\n")
+	fmt.Printf("\t-c\tcomma-separated header names or 1-based indexes of the columns to check in csv and tsv modes\n")
 }
 
 func validateFilename(filename string) (string, error) {
@@ -34,9 +61,290 @@ func validateFilename(filename string) (string, error) {
 	return filename, nil
 }
 
+// compileCommand builds a dictionary from a word list and writes it in the compiled format, which loads faster.
+func compileCommand(args []string) error {
+	if len(args) < 2 {
+		usage()
+		return nil
+	}
+	f, err := openDictionary(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dictionary := newDawgNode()
+	if report := dictionary.InsertAll(f); report.err != nil {
+		return report.err
+	}
+
+	out, err := os.Create(args[1])
+	if err != nil {
+		return err
+	}
+	if err := writeCompiledDictionary(out, dictionary); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// dictionaryFile is an open WORDLIST argument: a word list, or a compiled dictionary that can be
+// read from the start or mapped.
+type dictionaryFile struct {
+	*bufio.Reader
+	file     *os.File
+	compiled bool
+	mapped   *MappedDictionary
+}
+
+// openDictionary validates and opens the WORDLIST at path and detects whether it is compiled.
+func openDictionary(path string) (*dictionaryFile, error) {
+	wordFile, err := validateFilename(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(wordFile)
+	if err != nil {
+		return nil, err
+	}
+	d := &dictionaryFile{Reader: bufio.NewReader(f), file: f}
+	d.compiled = isCompiledDictionary(d.Reader)
+	return d, nil
+}
+
+// mapDictionary maps the compiled dictionary, which stays mapped until the file is closed.
+func (d *dictionaryFile) mapDictionary() (*MappedDictionary, error) {
+	compiled, err := mapDictionary(d.file)
+	if err != nil {
+		return nil, err
+	}
+	d.mapped = compiled
+	return compiled, nil
+}
+
+// Close unmaps the dictionary if it was mapped and closes the file, logging any error.
+func (d *dictionaryFile) Close() {
+	if d.mapped != nil {
+		if err := d.mapped.Close(); err != nil {
+			log.Printf("Error unmapping dictionary: %v", err)
+		}
+	}
+	if err := d.file.Close(); err != nil {
+		log.Printf("Error closing file: %v", err)
+	}
+}
+
+// loadDictionary reads a word list into a TrieNode, or a compiled dictionary into a DAWG.
+func loadDictionary(path string) (Trie, error) {
+	f, err := openDictionary(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if f.compiled {
+		return readCompiledDictionary(f)
+	}
+	trie := newTrieNode()
+	if report := trie.InsertAllParallel(f); report.err != nil {
+		return nil, report.err
+	}
+	return trie, nil
+}
+
+// anagramCommand prints the words in a dictionary that are anagrams of the given letters.
+func anagramCommand(args []string) error {
+	flags := flag.NewFlagSet("anagram", flag.ContinueOnError)
+	subset := flags.Bool("subset", false, "also print words that use only some of the letters")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 2 {
+		usage()
+		return nil
+	}
+	dictionary, err := loadDictionary(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	finder, ok := dictionary.(anagramFinder)
+	if !ok {
+		return errors.New("dictionary does not support anagram lookups")
+	}
+	for _, word := range finder.Anagrams(strings.ToLower(flags.Arg(1)), *subset) {
+		fmt.Println(word)
+	}
+	return nil
+}
+
+// dictCommand runs the "dict" subcommand named by the first argument.
+func dictCommand(args []string) error {
+	if len(args) < 1 {
+		usage()
+		return nil
+	}
+	command, ok := dictCommands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown dict command '%s'", args[0])
+	}
+	return command(args[1:])
+}
+
+// dictStatsCommand prints the shape and estimated memory use of a word list loaded into the
+// representation chosen with -d, or of a compiled dictionary as mapped.
+func dictStatsCommand(args []string) error {
+	flags := flag.NewFlagSet("dict stats", flag.ContinueOnError)
+	representation := flags.String("d", "trie", "dictionary representation (trie, radix, dawg, sync, persistent, bloom, membership)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 {
+		usage()
+		return nil
+	}
+	f, err := openDictionary(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var dictionary Trie
+	if f.compiled {
+		compiled, err := f.mapDictionary()
+		if err != nil {
+			return err
+		}
+		dictionary = compiled
+	} else {
+		newTrie, err := getTrieImplementation(*representation)
+		if err != nil {
+			return err
+		}
+		dictionary = newTrie()
+		if report := insertWordList(dictionary, f); report.err != nil {
+			return report.err
+		}
+	}
+	reporter, ok := dictionary.(statsReporter)
+	if !ok {
+		return errors.New("dictionary does not report statistics")
+	}
+	reporter.Stats().write(os.Stdout)
+	return nil
+}
+
+// dictExportCommand prints the trie of the words under a prefix as a Graphviz graph or nested JSON.
+func dictExportCommand(args []string) error {
+	flags := flag.NewFlagSet("dict export", flag.ContinueOnError)
+	format := flags.String("format", "dot", "output format (dot, json)")
+	prefix := flags.String("prefix", "", "export only the words starting with this prefix")
+	depth := flags.Int("depth", 0, "number of levels below the prefix to export, or 0 for all")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 {
+		usage()
+		return nil
+	}
+	export, ok := exportFormats[*format]
+	if !ok {
+		return fmt.Errorf("unknown export format '%s'; expected dot or json", *format)
+	}
+	dictionary, err := loadDictionary(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	// compiled dictionaries are exported as the trie of their words
+	trie, ok := dictionary.(*TrieNode)
+	if !ok {
+		trie = newTrieNode()
+		dictionary.Walk(*prefix, func(word string) bool {
+			return trie.Insert(word)
+		})
+	}
+	return export(trie, os.Stdout, *prefix, *depth)
+}
+
+// completeCommand prints the words completing a prefix within an edit distance of the input with
+// that distance, closest first and then most frequent in the word list.
+func completeCommand(args []string) error {
+	flags := flag.NewFlagSet("complete", flag.ContinueOnError)
+	maxDistance := flags.Int("k", 1, "maximum number of edits between the input and a prefix of a completion")
+	limit := flags.Int("n", 10, "maximum number of completions to print, or 0 for all")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 2 {
+		usage()
+		return nil
+	}
+	f, err := openDictionary(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var words []string
+	if f.compiled {
+		// compiled dictionaries hold each word once, so their words are ranked by distance alone
+		compiled, err := f.mapDictionary()
+		if err != nil {
+			return err
+		}
+		words = compiled.Enumerate()
+	} else {
+		var report LoadReport
+		words, report = readWords(f)
+		if report.err != nil {
+			return report.err
+		}
+	}
+	trie := newFrequencyTrie(words)
+	input := strings.ToLower(flags.Arg(1))
+	for _, completion := range trie.FuzzyComplete(input, *maxDistance, *limit, func(frequency int) int { return frequency }) {
+		fmt.Printf("%s\t%d\n", completion.key, completion.distance)
+	}
+	return nil
+}
+
+// setCommand returns a subcommand that prints, in lexicographic order, the words resulting from
+// applying operation to two dictionaries.
+func setCommand(operation func(a, b Trie, newTrie func() Trie) Trie) func(args []string) error {
+	return func(args []string) error {
+		if len(args) < 2 {
+			usage()
+			return nil
+		}
+		a, err := loadDictionary(args[0])
+		if err != nil {
+			return err
+		}
+		b, err := loadDictionary(args[1])
+		if err != nil {
+			return err
+		}
+		result := operation(a, b, func() Trie { return newTrieNode() })
+		result.Walk("", func(word string) bool {
+			fmt.Println(word)
+			return true
+		})
+		return nil
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if subcommand, ok := subcommands[os.Args[1]]; ok {
+			if err := subcommand(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
 
 	suggestions := flag.Int("s", 0, "number of words to suggest for each misspelling")
+	mode := flag.String("m", "", "input mode (text, docx, odt, epub, commit, mail, csv, tsv, man)")
+	columns := flag.String("c", "", "columns to check in csv and tsv modes")
+	dictionary := flag.String("d", "trie", "dictionary representation (trie, radix, dawg, sync, persistent, bloom, membership)")
 	flag.Parse()
 	if flag.NArg() < 2 {
 		usage()
@@ -45,23 +353,32 @@ func main() {
 	wordFile := flag.Arg(0)
 	targetPath := flag.Arg(1)
 
-	sanitizedFilepath, err := validateFilename(wordFile)
-	if err != nil {
-		log.Fatal(err)
-	}
-	f, err := os.Open(sanitizedFilepath)
+	f, err := openDictionary(wordFile)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer func(f *os.File) {
-		err := f.Close()
+	defer f.Close()
+
+	var spellcheck Spellcheck
+	if f.compiled {
+		compiled, err := f.mapDictionary()
 		if err != nil {
-			log.Printf("Error closing file: %v", err)
+			log.Fatal(err)
 		}
-	}(f)
-
-	spellcheck := newSpellcheck(*suggestions)
-	spellcheck.InitializeWordList(f)
+		spellcheck = newSpellcheckFromTrie(*suggestions, compiled)
+	} else {
+		newTrie, err := getTrieImplementation(*dictionary)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *dictionary == membershipDictionary && *suggestions > 0 {
+			log.Fatal("the membership dictionary cannot suggest words; use another with -s")
+		}
+		spellcheck = newSpellcheckWithTrie(*suggestions, newTrie)
+		if report := spellcheck.InitializeWordList(f); report.err != nil {
+			log.Fatalf("%s: %v", wordFile, report.err)
+		}
+	}
 
 	var targetReader io.Reader
 	if "-" == targetPath {
@@ -86,11 +403,36 @@ func main() {
 		targetReader = bufio.NewReader(targetFile)
 
 	}
-	spellingErrorsCh := spellcheck.CheckReader(targetReader)
+	if *mode == "" {
+		*mode = detectMode(targetPath)
+	}
+	var spellingErrorsCh chan SpellingError
+	if *mode == textMode && *columns == "" {
+		spellingErrorsCh = spellcheck.CheckReader(targetReader)
+	} else {
+		var extractor Extractor
+		if *columns != "" {
+			extractor, err = getDelimitedExtractor(*mode, strings.Split(*columns, ","))
+		} else {
+			extractor, err = getExtractor(*mode)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		segments, err := extractor(targetReader)
+		if err != nil {
+			log.Fatal(err)
+		}
+		spellingErrorsCh = spellcheck.CheckSegments(sliceToChan(segments))
+	}
 	spellingErrorsSlice := chanToSortedSlice(spellingErrorsCh, func(a, b SpellingError) int {
 		return a.line - b.line
 	})
 	for _, spellingError := range spellingErrorsSlice {
 		fmt.Printf("%s\n", spellingError.String())
 	}
+	// commit-msg hooks reject the commit on a non-zero exit status
+	if *mode == commitMode && len(spellingErrorsSlice) > 0 {
+		os.Exit(1)
+	}
 }
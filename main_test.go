@@ -1,6 +1,9 @@
 package main
 
 import (
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -26,3 +29,43 @@ func TestValidateFilename_DoesNotExist(t *testing.T) {
 		t.Fatal("Expected error; got no error")
 	}
 }
+
+func TestLoadDictionary(t *testing.T) {
+	dir := t.TempDir()
+	wordFile := dir + "/words.txt"
+	if err := os.WriteFile(wordFile, []byte("listen\nsilent\ntinsel\nlist\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	compiledFile := dir + "/words.dict"
+	if err := compileCommand([]string{wordFile, compiledFile}); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{wordFile, compiledFile} {
+		dictionary, err := loadDictionary(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		finder, ok := dictionary.(anagramFinder)
+		if !ok {
+			t.Fatalf("Expected %s to support anagram lookups", path)
+		}
+		expected := []string{"listen", "silent", "tinsel"}
+		actual := finder.Anagrams("enlist", false)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("%s\nExpected:\t%v\nActual:\t\t%v\n", path, expected, actual)
+		}
+	}
+}
+
+func TestLoadDictionary_LineTooLong(t *testing.T) {
+	wordFile := t.TempDir() + "/words.txt"
+	if err := os.WriteFile(wordFile, []byte("the\n"+strings.Repeat("a", 70000)+"\nzebra\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadDictionary(wordFile); err == nil {
+		t.Fatal("Expected error; got no error")
+	}
+	if err := compileCommand([]string{wordFile, t.TempDir() + "/words.dict"}); err == nil {
+		t.Fatal("Expected error; got no error")
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MappedDictionary is a read-only Trie that looks keys up directly in a memory-mapped compiled
+// dictionary. Nothing is deserialized, so it is ready as soon as the file is mapped, and
+// processes using the same dictionary share its pages.
+//
+// Only the header and size are checked when mapping, not the checksum, which would read the
+// whole file; lookups bounds-check every node and edge instead and treat anything out of range
+// as missing.
+type MappedDictionary struct {
+	nodes     []byte
+	edges     []byte
+	nodeCount uint32
+	edgeCount uint32
+	unmap     func() error
+}
+
+// mapDictionary maps the compiled dictionary in f.
+func mapDictionary(f *os.File) (*MappedDictionary, error) {
+	data, unmap, err := mmapFile(f)
+	if err != nil {
+		return nil, err
+	}
+	nodeCount, edgeCount, err := compiledHeader(data)
+	if err != nil {
+		_ = unmap()
+		return nil, err
+	}
+	nodes := data[headerSize : headerSize+int(nodeCount)*nodeSize]
+	return &MappedDictionary{
+		nodes:     nodes,
+		edges:     data[headerSize+len(nodes) : len(data)-trailerSize],
+		nodeCount: nodeCount,
+		edgeCount: edgeCount,
+		unmap:     unmap,
+	}, nil
+}
+
+// Close unmaps the dictionary; it must not be used afterwards.
+func (d *MappedDictionary) Close() error {
+	return d.unmap()
+}
+
+// node returns the edge range and finality of node i, and the number of keys in its subgraph.
+func (d *MappedDictionary) node(i uint32) (uint32, uint32, bool, int) {
+	firstEdge := binary.LittleEndian.Uint32(d.nodes[int(i)*nodeSize:])
+	info := binary.LittleEndian.Uint32(d.nodes[int(i)*nodeSize+4:])
+	keys := int(binary.LittleEndian.Uint32(d.nodes[int(i)*nodeSize+8:]))
+	count := info >> 1
+	if uint64(firstEdge)+uint64(count) > uint64(d.edgeCount) {
+		return 0, 0, false, 0
+	}
+	return firstEdge, count, info&1 == 1, keys
+}
+
+func (d *MappedDictionary) edge(j uint32) (rune, uint32) {
+	return rune(binary.LittleEndian.Uint32(d.edges[int(j)*edgeSize:])), binary.LittleEndian.Uint32(d.edges[int(j)*edgeSize+4:])
+}
+
+// child binary searches the edges of node i for c. Edges must lead forward, which rules out cycles.
+func (d *MappedDictionary) child(i uint32, c rune) (uint32, bool) {
+	firstEdge, count, _, _ := d.node(i)
+	lo, hi := firstEdge, firstEdge+count
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if edgeRune, _ := d.edge(mid); edgeRune < c {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo == firstEdge+count {
+		return 0, false
+	}
+	edgeRune, target := d.edge(lo)
+	if edgeRune != c || target <= i || target >= d.nodeCount {
+		return 0, false
+	}
+	return target, true
+}
+
+func (d *MappedDictionary) Contains(key string) bool {
+	currentNode := uint32(0)
+	for _, c := range key {
+		child, hasChild := d.child(currentNode, c)
+		if !hasChild {
+			return false
+		}
+		currentNode = child
+	}
+	_, _, isKey, _ := d.node(currentNode)
+	return isKey
+}
+
+func (d *MappedDictionary) walk(s string) (string, uint32) {
+	currentNode := uint32(0)
+	for i, c := range s {
+		child, hasChild := d.child(currentNode, c)
+		if !hasChild {
+			return s[:i], currentNode
+		}
+		currentNode = child
+	}
+	return s, currentNode
+}
+
+func (d *MappedDictionary) LongestPrefix(s string) string {
+	prefix, _ := d.walk(s)
+	return prefix
+}
+
+func (d *MappedDictionary) KeysWithCommonPrefix(s string) []string {
+	prefix, node := d.walk(s)
+	if len(prefix) == 0 {
+		return []string{}
+	}
+	keys := d.enumerateFrom(node)
+	slices.Sort(keys)
+	for i := 0; i < len(keys); i++ {
+		keys[i] = prefix + keys[i]
+	}
+	return keys
+}
+
+func (d *MappedDictionary) Walk(prefix string, visit func(key string) bool) {
+	matched, node := d.walk(prefix)
+	if len(matched) < len(prefix) {
+		return
+	}
+	d.walkKeys(node, []byte(prefix), visit)
+}
+
+func (d *MappedDictionary) walkKeys(node uint32, key []byte, visit func(key string) bool) bool {
+	firstEdge, count, isKey, _ := d.node(node)
+	if isKey && !visit(string(key)) {
+		return false
+	}
+	for j := firstEdge; j < firstEdge+count; j++ {
+		c, target := d.edge(j)
+		if target <= node || target >= d.nodeCount || !utf8.ValidRune(c) {
+			continue
+		}
+		if !d.walkKeys(target, utf8.AppendRune(key, c), visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// CountWithPrefix reads the key count stored with the node prefix leads to. Counts aren't
+// checked when mapping, so a corrupt dictionary may report a count that Walk doesn't match.
+func (d *MappedDictionary) CountWithPrefix(prefix string) int {
+	matched, node := d.walk(prefix)
+	if len(matched) < len(prefix) {
+		return 0
+	}
+	_, _, _, keys := d.node(node)
+	return keys
+}
+
+// Size returns the number of keys in the dictionary.
+func (d *MappedDictionary) Size() int {
+	return d.CountWithPrefix("")
+}
+
+type mappedPath struct {
+	prefix string
+	node   uint32
+}
+
+func (d *MappedDictionary) enumerateFrom(root uint32) []string {
+	var neighborStack = make(stack[mappedPath], 0)
+	var enumeration []string
+	neighborStack.push(mappedPath{"", root})
+
+	for neighborStack.size() > 0 {
+		currentPath := neighborStack.pop()
+		firstEdge, count, isKey, _ := d.node(currentPath.node)
+		if isKey {
+			enumeration = append(enumeration, currentPath.prefix)
+		}
+		for j := firstEdge; j < firstEdge+count; j++ {
+			c, target := d.edge(j)
+			if target <= currentPath.node || target >= d.nodeCount || !utf8.ValidRune(c) {
+				continue
+			}
+			neighborStack.push(mappedPath{
+				prefix: currentPath.prefix + string(c),
+				node:   target,
+			})
+		}
+	}
+	return enumeration
+}
+
+func (d *MappedDictionary) Enumerate() []string {
+	return d.enumerateFrom(0)
+}
+
+// Insert is not supported by the read-only MappedDictionary and returns false.
+func (d *MappedDictionary) Insert(key string) bool {
+	return false
+}
+
+// InsertAll is not supported by the read-only MappedDictionary, which it leaves unchanged.
+func (d *MappedDictionary) InsertAll(r io.Reader) LoadReport {
+	return LoadReport{err: errReadOnly}
+}
+
+// Delete is not supported by the read-only MappedDictionary and returns false.
+func (d *MappedDictionary) Delete(key string) bool {
+	return false
+}
+
+// DeleteAll is not supported by the read-only MappedDictionary, which it leaves unchanged.
+func (d *MappedDictionary) DeleteAll(r io.Reader) LoadReport {
+	return LoadReport{err: errReadOnly}
+}
+
+func (d *MappedDictionary) String() string {
+	return strings.Join(d.Enumerate(), "\n")
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/binary"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mapWords(t *testing.T, data []byte) *MappedDictionary {
+	path := t.TempDir() + "/words.dict"
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	d, err := mapDictionary(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := d.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return d
+}
+
+func TestMappedDictionary(t *testing.T) {
+	words := []string{"a", "abx", "abcx", "abcdx", "abcdex", "abcdey", "abcdez", "naïve"}
+	var trie Trie = mapWords(t, compileWords(t, words))
+	for _, key := range words {
+		if !trie.Contains(key) {
+			t.Fatalf("Expected dictionary to contain %v\n", key)
+		}
+	}
+	if trie.Contains("ab") || trie.Contains("abcdexx") || trie.Contains("") {
+		t.Fatalf("Did not expect dictionary to contain prefixes or extensions of keys")
+	}
+	if actual := trie.LongestPrefix("abcdefg"); actual != "abcde" {
+		t.Fatalf("\nExpected:\tabcde\nActual:\t\t%v\n", actual)
+	}
+	expected := []string{"abcdex", "abcdey", "abcdez"}
+	actual := trie.KeysWithCommonPrefix("abcdefg")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	if len(trie.Enumerate()) != len(words) {
+		t.Fatalf("Expected %d keys, got %v", len(words), trie.Enumerate())
+	}
+	if trie.Insert("xyz") || trie.Delete("a") || !trie.Contains("a") {
+		t.Fatalf("Expected the mapped dictionary to be read-only")
+	}
+}
+
+func TestMappedDictionary_BackwardEdge(t *testing.T) {
+	data := compileWords(t, []string{"ab"})
+	// point the root's only edge back at the root; lookups must not loop or panic
+	edgeTarget := headerSize + 3*nodeSize + 4
+	binary.LittleEndian.PutUint32(data[edgeTarget:], 0)
+	d := mapWords(t, data)
+	if d.Contains("ab") || len(d.Enumerate()) != 0 {
+		t.Fatalf("Expected the invalid edge to be ignored")
+	}
+}
+
+func TestMapDictionary_NotCompiled(t *testing.T) {
+	path := t.TempDir() + "/words.txt"
+	if err := os.WriteFile(path, []byte(strings.Repeat("word\n", 10)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := mapDictionary(f); err == nil {
+		t.Fatal("Expected error; got no error")
+	}
+}
+
+func TestMappedDictionaryWalk(t *testing.T) {
+	d := mapWords(t, compileWords(t, []string{"these", "the", "theses", "them", "zebra"}))
+	var actual []string
+	d.Walk("the", func(key string) bool {
+		actual = append(actual, key)
+		return len(actual) < 3
+	})
+	expected := []string{"the", "them", "these"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestMappedDictionaryCountWithPrefix(t *testing.T) {
+	d := mapWords(t, compileWords(t, []string{"walking", "talking", "walked", "talked", "walk"}))
+	if actual := d.Size(); actual != 5 {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", 5, actual)
+	}
+	if actual := d.CountWithPrefix("walk"); actual != 3 {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", 3, actual)
+	}
+	if actual := d.CountWithPrefix("walx"); actual != 0 {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", 0, actual)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris)
+
+package main
+
+import (
+	"io"
+	"os"
+)
+
+// mmapFile reads the contents of f into memory on platforms without mmap.
+func mmapFile(f *os.File) ([]byte, func() error, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := io.ReadAll(io.NewSectionReader(f, 0, info.Size()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
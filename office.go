@@ -0,0 +1,341 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	wordprocessingNs = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	odfTextNs        = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+)
+
+func openZip(r io.Reader) (*zip.Reader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
+
+func readZipFile(z *zip.Reader, name string) ([]byte, error) {
+	for _, f := range z.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer func(rc io.ReadCloser) {
+			_ = rc.Close()
+		}(rc)
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("archive has no entry %s", name)
+}
+
+// paragraphBuilder accumulates the text of nested paragraphs while walking an XML document.
+// Whitespace within each paragraph is collapsed to single spaces.
+type paragraphBuilder struct {
+	open       []*strings.Builder
+	paragraphs []string
+}
+
+func (pb *paragraphBuilder) start() {
+	pb.open = append(pb.open, &strings.Builder{})
+}
+
+func (pb *paragraphBuilder) write(s string) {
+	if len(pb.open) > 0 {
+		pb.open[len(pb.open)-1].WriteString(s)
+	}
+}
+
+func (pb *paragraphBuilder) end() {
+	if len(pb.open) == 0 {
+		return
+	}
+	n := len(pb.open) - 1
+	text := strings.Join(strings.Fields(pb.open[n].String()), " ")
+	pb.open = pb.open[:n]
+	if len(text) > 0 {
+		pb.paragraphs = append(pb.paragraphs, text)
+	}
+}
+
+func docxParagraphs(r io.Reader) ([]string, error) {
+	decoder := xml.NewDecoder(r)
+	var pb paragraphBuilder
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return pb.paragraphs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Space != wordprocessingNs {
+				continue
+			}
+			switch t.Name.Local {
+			case "p":
+				pb.start()
+			case "t":
+				inText = true
+			case "tab":
+				pb.write("\t")
+			case "br", "cr":
+				pb.write(" ")
+			}
+		case xml.EndElement:
+			if t.Name.Space != wordprocessingNs {
+				continue
+			}
+			switch t.Name.Local {
+			case "p":
+				pb.end()
+			case "t":
+				inText = false
+			}
+		case xml.CharData:
+			if inText {
+				pb.write(string(t))
+			}
+		}
+	}
+}
+
+func odtParagraphs(r io.Reader) ([]string, error) {
+	decoder := xml.NewDecoder(r)
+	var pb paragraphBuilder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return pb.paragraphs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Space != odfTextNs {
+				continue
+			}
+			switch t.Name.Local {
+			case "p", "h":
+				pb.start()
+			case "s":
+				spaces := 1
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "c" {
+						if c, err := strconv.Atoi(attr.Value); err == nil {
+							spaces = c
+						}
+					}
+				}
+				pb.write(strings.Repeat(" ", spaces))
+			case "tab":
+				pb.write("\t")
+			case "line-break":
+				pb.write(" ")
+			}
+		case xml.EndElement:
+			if t.Name.Space == odfTextNs && (t.Name.Local == "p" || t.Name.Local == "h") {
+				pb.end()
+			}
+		case xml.CharData:
+			pb.write(string(t))
+		}
+	}
+}
+
+var xhtmlBlocks = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"li": true, "dt": true, "dd": true, "td": true, "th": true, "caption": true,
+	"blockquote": true, "pre": true, "figcaption": true, "div": true,
+}
+
+func xhtmlParagraphs(r io.Reader) ([]string, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+	var pb paragraphBuilder
+	skipDepth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return pb.paragraphs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if name == "script" || name == "style" || skipDepth > 0 {
+				skipDepth++
+			} else if xhtmlBlocks[name] {
+				pb.start()
+			} else if name == "br" {
+				pb.write(" ")
+			}
+		case xml.EndElement:
+			name := strings.ToLower(t.Name.Local)
+			if skipDepth > 0 {
+				skipDepth--
+			} else if xhtmlBlocks[name] {
+				pb.end()
+			}
+		case xml.CharData:
+			if skipDepth == 0 {
+				pb.write(string(t))
+			}
+		}
+	}
+}
+
+func paragraphSegments(paragraphs []string) []Segment {
+	segments := make([]Segment, len(paragraphs))
+	for i, paragraph := range paragraphs {
+		segments[i] = Segment{
+			text:     paragraph,
+			line:     i + 1,
+			location: fmt.Sprintf("Paragraph %d", i+1),
+		}
+	}
+	return segments
+}
+
+func extractDocx(r io.Reader) ([]Segment, error) {
+	z, err := openZip(r)
+	if err != nil {
+		return nil, err
+	}
+	document, err := readZipFile(z, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	paragraphs, err := docxParagraphs(bytes.NewReader(document))
+	if err != nil {
+		return nil, err
+	}
+	return paragraphSegments(paragraphs), nil
+}
+
+func extractOdt(r io.Reader) ([]Segment, error) {
+	z, err := openZip(r)
+	if err != nil {
+		return nil, err
+	}
+	content, err := readZipFile(z, "content.xml")
+	if err != nil {
+		return nil, err
+	}
+	paragraphs, err := odtParagraphs(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	return paragraphSegments(paragraphs), nil
+}
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type epubPackage struct {
+	Items []struct {
+		Id        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	Itemrefs []struct {
+		Idref string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// epubChapters returns the archive paths of the package's content documents in reading order.
+func epubChapters(z *zip.Reader) ([]string, error) {
+	containerXml, err := readZipFile(z, "META-INF/container.xml")
+	if err != nil {
+		return nil, err
+	}
+	var container epubContainer
+	if err := xml.Unmarshal(containerXml, &container); err != nil {
+		return nil, err
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, errors.New("epub container lists no rootfile")
+	}
+	opfPath := container.Rootfiles[0].FullPath
+	opfXml, err := readZipFile(z, opfPath)
+	if err != nil {
+		return nil, err
+	}
+	var pkg epubPackage
+	if err := xml.Unmarshal(opfXml, &pkg); err != nil {
+		return nil, err
+	}
+	hrefs := make(map[string]string)
+	for _, item := range pkg.Items {
+		if item.MediaType == "application/xhtml+xml" || item.MediaType == "text/html" {
+			hrefs[item.Id] = item.Href
+		}
+	}
+	var chapters []string
+	for _, itemref := range pkg.Itemrefs {
+		href, ok := hrefs[itemref.Idref]
+		if !ok {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+		chapters = append(chapters, path.Join(path.Dir(opfPath), href))
+	}
+	return chapters, nil
+}
+
+func extractEpub(r io.Reader) ([]Segment, error) {
+	z, err := openZip(r)
+	if err != nil {
+		return nil, err
+	}
+	chapters, err := epubChapters(z)
+	if err != nil {
+		return nil, err
+	}
+	var segments []Segment
+	for c, chapter := range chapters {
+		content, err := readZipFile(z, chapter)
+		if err != nil {
+			return nil, err
+		}
+		paragraphs, err := xhtmlParagraphs(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", chapter, err)
+		}
+		for p, paragraph := range paragraphs {
+			segments = append(segments, Segment{
+				text:     paragraph,
+				line:     len(segments) + 1,
+				location: fmt.Sprintf("Chapter %d, paragraph %d", c+1, p+1),
+			})
+		}
+	}
+	return segments, nil
+}
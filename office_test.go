@@ -0,0 +1,115 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func zipArchive(t *testing.T, files map[string]string) *bytes.Reader {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func segmentLocations(segments []Segment) []string {
+	locations := make([]string, len(segments))
+	for i, segment := range segments {
+		locations[i] = segment.location + ": " + segment.text
+	}
+	return locations
+}
+
+func TestExtractDocx(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t>Thes are</w:t></w:r><w:r><w:t xml:space="preserve"> wrds</w:t></w:r></w:p>
+<w:p></w:p>
+<w:p><w:r><w:t>One</w:t><w:tab/><w:t>two</w:t><w:br/><w:t>three</w:t></w:r></w:p>
+</w:body>
+</w:document>`
+	segments, err := extractDocx(zipArchive(t, map[string]string{"word/document.xml": document}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"Paragraph 1: Thes are wrds", "Paragraph 2: One two three"}
+	actual := segmentLocations(segments)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestExtractOdt(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:text>
+<text:h>A <text:span>heading</text:span></text:h>
+<text:p>Some<text:s text:c="3"/>spaced<text:line-break/>text</text:p>
+</office:text></office:body>
+</office:document-content>`
+	segments, err := extractOdt(zipArchive(t, map[string]string{"content.xml": content}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"Paragraph 1: A heading", "Paragraph 2: Some spaced text"}
+	actual := segmentLocations(segments)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestExtractEpub(t *testing.T) {
+	files := map[string]string{
+		"META-INF/container.xml": `<?xml version="1.0"?>
+<container xmlns="urn:oasis:names:tc:opendocument:xmlns:container" version="1.0">
+<rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`,
+		"OEBPS/content.opf": `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+<manifest>
+<item id="c2" href="text/chapter%202.xhtml" media-type="application/xhtml+xml"/>
+<item id="c1" href="text/chapter1.xhtml" media-type="application/xhtml+xml"/>
+<item id="css" href="style.css" media-type="text/css"/>
+</manifest>
+<spine><itemref idref="c1"/><itemref idref="c2"/></spine>
+</package>`,
+		"OEBPS/text/chapter1.xhtml": `<html><head><title>Ignored</title><style>p { color: red }</style></head>
+<body><h1>First&nbsp;chapter</h1><p>It was a
+dark night.</p></body></html>`,
+		"OEBPS/text/chapter 2.xhtml": `<html><body><p>The <em>end</em>.</p></body></html>`,
+	}
+	segments, err := extractEpub(zipArchive(t, files))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"Chapter 1, paragraph 1: First chapter",
+		"Chapter 1, paragraph 2: It was a dark night.",
+		"Chapter 2, paragraph 1: The end.",
+	}
+	actual := segmentLocations(segments)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestExtractDocx_NotAnArchive(t *testing.T) {
+	_, err := extractDocx(bytes.NewReader([]byte("plain text")))
+	if err == nil {
+		t.Fatal("Expected error; got no error")
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"io"
+	"runtime"
+	"slices"
+	"sync"
+	"unicode/utf8"
+)
+
+// parallelInserter is implemented by dictionaries that can build from a word list concurrently.
+type parallelInserter interface {
+	InsertAllParallel(r io.Reader) LoadReport
+}
+
+// insertWordList adds the newline-separated keys read from r to trie, concurrently when the trie supports it.
+func insertWordList(trie Trie, r io.Reader) LoadReport {
+	if inserter, ok := trie.(parallelInserter); ok {
+		return inserter.InsertAllParallel(r)
+	}
+	return trie.InsertAll(r)
+}
+
+// trieShard is the rest of each key starting with one rune, to be inserted below that rune's child.
+type trieShard struct {
+	node *ValueTrie[struct{}]
+	keys []string
+}
+
+// InsertAllParallel adds the newline-separated keys read from r like InsertAll, but shards them by
+// first rune and builds the subtrie under each first rune concurrently. Shards that are already
+// sorted and land in an empty subtrie are built without looking up the prefix shared with the
+// previous key.
+func (t *TrieNode) InsertAllParallel(r io.Reader) LoadReport {
+	shards := make(map[rune]*trieShard)
+	report := readWordList(r, func(key string) {
+		c, size := utf8.DecodeRuneInString(key)
+		shard, ok := shards[c]
+		if !ok {
+			// subtries are linked under the root up front so workers never write to the root
+			child, hasChild := t.children[c]
+			if !hasChild {
+				child = newValueTrie[struct{}]()
+				t.children[c] = child
+			}
+			shard = &trieShard{node: child}
+			shards[c] = shard
+		}
+		shard.keys = append(shard.keys, key[size:])
+	})
+
+	jobs := make(chan *trieShard)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for shard := range jobs {
+				shard.build()
+			}
+		}()
+	}
+	for _, shard := range shards {
+		jobs <- shard
+	}
+	close(jobs)
+	wg.Wait()
+
+	t.count = 0
+	if t.isKey {
+		t.count = 1
+	}
+	for _, child := range t.children {
+		t.count += child.count
+	}
+	return report
+}
+
+func (shard *trieShard) build() {
+	if shard.node.count == 0 && slices.IsSorted(shard.keys) {
+		insertSorted(shard.node, shard.keys)
+		return
+	}
+	for _, key := range shard.keys {
+		shard.node.Put(key, struct{}{})
+	}
+}
+
+// insertSorted adds sorted keys to an empty trie. Keys sharing a prefix are adjacent, so each key
+// continues from the nodes of the previous one where they diverge.
+func insertSorted(root *ValueTrie[struct{}], keys []string) {
+	path := []*ValueTrie[struct{}]{root}
+	var previous []rune
+	for i, key := range keys {
+		chars := []rune(key)
+		if i > 0 && slices.Equal(chars, previous) {
+			continue
+		}
+		n := 0
+		for n < len(chars) && n < len(previous) && chars[n] == previous[n] {
+			n++
+		}
+		path = path[:n+1]
+		for _, node := range path {
+			node.count++
+		}
+		node := path[n]
+		for _, c := range chars[n:] {
+			child := newValueTrie[struct{}]()
+			child.count = 1
+			node.children[c] = child
+			path = append(path, child)
+			node = child
+		}
+		node.isKey = true
+		previous = chars
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInsertAllParallel(t *testing.T) {
+	tests := map[string][]string{
+		"unsorted":    {"thesis", "these", "zebra", "the", "theseus", "a", "thé", "", "these", "ant"},
+		"sorted":      {"", "a", "ant", "ant", "the", "these", "theseus", "thesis", "thé", "zebra"},
+		"single rune": {"a", "b", "c"},
+	}
+	for name, wordList := range tests {
+		expected := newTrieNode()
+		expected.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+		actual := newTrieNode()
+		actual.InsertAllParallel(strings.NewReader(strings.Join(wordList, "\n")))
+
+		expectedKeys, actualKeys := expected.Enumerate(), actual.Enumerate()
+		slices.Sort(expectedKeys)
+		slices.Sort(actualKeys)
+		if !reflect.DeepEqual(actualKeys, expectedKeys) {
+			t.Fatalf("%s\nExpected:\t%v\nActual:\t\t%v\n", name, expectedKeys, actualKeys)
+		}
+		for _, prefix := range []string{"", "a", "the", "thes", "z", "x"} {
+			if expected.CountWithPrefix(prefix) != actual.CountWithPrefix(prefix) {
+				t.Fatalf("%s: count with prefix %q\nExpected:\t%v\nActual:\t\t%v\n",
+					name, prefix, expected.CountWithPrefix(prefix), actual.CountWithPrefix(prefix))
+			}
+		}
+	}
+}
+
+func TestInsertAllParallel_NonEmpty(t *testing.T) {
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader("the\nzebra"))
+	trie.InsertAllParallel(strings.NewReader("them\nthe\nant"))
+	expected := []string{"ant", "the", "them", "zebra"}
+	actual := trie.Enumerate()
+	slices.Sort(actual)
+	if !reflect.DeepEqual(actual, expected) || trie.Size() != len(expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v (size %d)\n", expected, actual, trie.Size())
+	}
+}
+
+func benchmarkInsertAll(b *testing.B, sorted bool, insertAll func(trie *TrieNode, words []byte)) {
+	words, err := os.ReadFile("words.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	if sorted {
+		lines := strings.Split(string(words), "\n")
+		slices.Sort(lines)
+		words = []byte(strings.Join(lines, "\n"))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		insertAll(newTrieNode(), words)
+	}
+}
+
+func BenchmarkInsertAll(b *testing.B) {
+	benchmarkInsertAll(b, false, func(trie *TrieNode, words []byte) {
+		trie.InsertAll(bytes.NewReader(words))
+	})
+}
+
+func BenchmarkInsertAllParallel(b *testing.B) {
+	benchmarkInsertAll(b, false, func(trie *TrieNode, words []byte) {
+		trie.InsertAllParallel(bytes.NewReader(words))
+	})
+}
+
+func BenchmarkInsertAllSorted(b *testing.B) {
+	benchmarkInsertAll(b, true, func(trie *TrieNode, words []byte) {
+		trie.InsertAll(bytes.NewReader(words))
+	})
+}
+
+func BenchmarkInsertAllParallelSorted(b *testing.B) {
+	benchmarkInsertAll(b, true, func(trie *TrieNode, words []byte) {
+		trie.InsertAllParallel(bytes.NewReader(words))
+	})
+}
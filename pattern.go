@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"unicode/utf8"
+)
+
+type patternTokenKind int
+
+const (
+	literalToken patternTokenKind = iota
+	anyRuneToken
+	anyRunToken
+	classToken
+)
+
+type runeRange struct {
+	lo, hi rune
+}
+
+// patternToken is one element of a compiled pattern: a literal rune, '?', '*' or a character class.
+type patternToken struct {
+	kind    patternTokenKind
+	literal rune
+	ranges  []runeRange
+	negate  bool
+}
+
+func (token patternToken) matches(c rune) bool {
+	switch token.kind {
+	case literalToken:
+		return c == token.literal
+	case classToken:
+		for _, r := range token.ranges {
+			if c >= r.lo && c <= r.hi {
+				return !token.negate
+			}
+		}
+		return token.negate
+	}
+	return true
+}
+
+var errBadPattern = errors.New("syntax error in pattern")
+
+// compilePattern parses a pattern in which '?' matches one rune, '*' matches any run of runes,
+// '[...]' matches one rune from a class such as [aeiou], [a-f] or [!xyz], and '\' escapes the
+// next rune.
+func compilePattern(pattern string) ([]patternToken, error) {
+	var tokens []patternToken
+	chars := []rune(pattern)
+	for i := 0; i < len(chars); i++ {
+		switch c := chars[i]; c {
+		case '?':
+			tokens = append(tokens, patternToken{kind: anyRuneToken})
+		case '*':
+			// consecutive stars match the same keys as one
+			if len(tokens) == 0 || tokens[len(tokens)-1].kind != anyRunToken {
+				tokens = append(tokens, patternToken{kind: anyRunToken})
+			}
+		case '\\':
+			i++
+			if i == len(chars) {
+				return nil, errBadPattern
+			}
+			tokens = append(tokens, patternToken{kind: literalToken, literal: chars[i]})
+		case '[':
+			token := patternToken{kind: classToken}
+			i++
+			if i < len(chars) && (chars[i] == '!' || chars[i] == '^') {
+				token.negate = true
+				i++
+			}
+			start := i
+			for ; i < len(chars) && (chars[i] != ']' || i == start); i++ {
+				lo := chars[i]
+				hi := lo
+				if i+2 < len(chars) && chars[i+1] == '-' && chars[i+2] != ']' {
+					hi = chars[i+2]
+					i += 2
+				}
+				if lo > hi {
+					return nil, errBadPattern
+				}
+				token.ranges = append(token.ranges, runeRange{lo, hi})
+			}
+			if i == len(chars) {
+				return nil, errBadPattern
+			}
+			tokens = append(tokens, token)
+		default:
+			tokens = append(tokens, patternToken{kind: literalToken, literal: c})
+		}
+	}
+	return tokens, nil
+}
+
+type matchState[V any] struct {
+	node  *ValueTrie[V]
+	token int
+}
+
+// Match returns the keys matching pattern, in lexicographic order. See compilePattern for the
+// pattern syntax. Only branches that can still match are walked, so a pattern with a literal
+// prefix visits just the subtree under that prefix.
+func (t *ValueTrie[V]) Match(pattern string) ([]string, error) {
+	tokens, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	visited := make(map[matchState[V]]bool)
+	var match func(node *ValueTrie[V], token int, key []byte)
+	match = func(node *ValueTrie[V], token int, key []byte) {
+		state := matchState[V]{node, token}
+		if visited[state] {
+			return
+		}
+		visited[state] = true
+		if token == len(tokens) {
+			if node.isKey {
+				matches = append(matches, string(key))
+			}
+			return
+		}
+		current := tokens[token]
+		if current.kind == anyRunToken {
+			// match an empty run, or one more rune and stay on the star
+			match(node, token+1, key)
+			for c, child := range node.children {
+				match(child, token, utf8.AppendRune(key, c))
+			}
+			return
+		}
+		if current.kind == literalToken {
+			if child, hasChild := node.children[current.literal]; hasChild {
+				match(child, token+1, utf8.AppendRune(key, current.literal))
+			}
+			return
+		}
+		for c, child := range node.children {
+			if current.matches(c) {
+				match(child, token+1, utf8.AppendRune(key, c))
+			}
+		}
+	}
+	match(t, 0, []byte{})
+	slices.Sort(matches)
+	return matches, nil
+}
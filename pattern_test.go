@@ -0,0 +1,44 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	trie := newTrieNode()
+	wordList := []string{"cat", "cot", "cut", "coat", "scat", "cart", "car", "at", "c*t", "caté"}
+	trie.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+	cases := map[string][]string{
+		"c?t":     {"c*t", "cat", "cot", "cut"},
+		"c*t":     {"c*t", "cart", "cat", "coat", "cot", "cut"},
+		"*at":     {"at", "cat", "coat", "scat"},
+		"**a**":   {"at", "car", "cart", "cat", "caté", "coat", "scat"},
+		"c[ao]t":  {"cat", "cot"},
+		"c[!ao]t": {"c*t", "cut"},
+		"c[a-o]t": {"cat", "cot"},
+		"c\\*t":   {"c*t"},
+		"cat?":    {"caté"},
+		"dog*":    nil,
+		"":        nil,
+	}
+	for pattern, expected := range cases {
+		actual, err := trie.Match(pattern)
+		if err != nil {
+			t.Fatalf("Match(%q): %v", pattern, err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Match(%q)\nExpected:\t%v\nActual:\t\t%v\n", pattern, expected, actual)
+		}
+	}
+}
+
+func TestMatch_BadPattern(t *testing.T) {
+	trie := newTrieNode()
+	for _, pattern := range []string{"c[at", "ca\\", "[z-a]"} {
+		if _, err := trie.Match(pattern); err == nil {
+			t.Fatalf("Match(%q): expected error; got no error", pattern)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// PersistentTrie is an immutable snapshot of a trie. With and Without return a new version that
+// shares every node off the changed key's path with the old one, so checks already using a
+// snapshot are unaffected by later updates.
+type PersistentTrie struct {
+	root *persistentNode
+}
+
+// persistentNode is never modified once it is reachable from a snapshot.
+type persistentNode struct {
+	isKey bool
+	// count is the number of keys in the subtree rooted at this node, including its own
+	count int
+	edges []persistentEdge
+}
+
+// persistentEdge leads to a child node. Edges of a node are sorted by rune.
+type persistentEdge struct {
+	c    rune
+	node *persistentNode
+}
+
+func newPersistentTrie() *PersistentTrie {
+	return &PersistentTrie{&persistentNode{}}
+}
+
+func (n *persistentNode) findEdge(c rune) (int, bool) {
+	i := sort.Search(len(n.edges), func(i int) bool {
+		return n.edges[i].c >= c
+	})
+	return i, i < len(n.edges) && n.edges[i].c == c
+}
+
+// with returns a copy of n with the absent key spelled by chars added below it.
+func (n *persistentNode) with(chars []rune) *persistentNode {
+	copied := &persistentNode{isKey: n.isKey, count: n.count + 1, edges: n.edges}
+	if len(chars) == 0 {
+		copied.isKey = true
+		return copied
+	}
+	i, found := n.findEdge(chars[0])
+	if found {
+		copied.edges = slices.Clone(n.edges)
+		copied.edges[i].node = n.edges[i].node.with(chars[1:])
+	} else {
+		child := (&persistentNode{}).with(chars[1:])
+		copied.edges = slices.Insert(slices.Clip(n.edges), i, persistentEdge{chars[0], child})
+	}
+	return copied
+}
+
+// without returns a copy of n with the present key spelled by chars removed below it, or nil if
+// no keys are left under it.
+func (n *persistentNode) without(chars []rune) *persistentNode {
+	if n.count == 1 {
+		return nil
+	}
+	copied := &persistentNode{isKey: n.isKey, count: n.count - 1, edges: n.edges}
+	if len(chars) == 0 {
+		copied.isKey = false
+		return copied
+	}
+	i, _ := n.findEdge(chars[0])
+	copied.edges = slices.Clone(n.edges)
+	if child := n.edges[i].node.without(chars[1:]); child != nil {
+		copied.edges[i].node = child
+	} else {
+		copied.edges = slices.Delete(copied.edges, i, i+1)
+	}
+	return copied
+}
+
+// With returns a version of the trie that also contains key, or t itself if it already does.
+func (t *PersistentTrie) With(key string) *PersistentTrie {
+	if t.Contains(key) {
+		return t
+	}
+	return &PersistentTrie{t.root.with([]rune(key))}
+}
+
+// Without returns a version of the trie that does not contain key, or t itself if it already doesn't.
+func (t *PersistentTrie) Without(key string) *PersistentTrie {
+	if !t.Contains(key) {
+		return t
+	}
+	root := t.root.without([]rune(key))
+	if root == nil {
+		root = &persistentNode{}
+	}
+	return &PersistentTrie{root}
+}
+
+// Insert is not supported by the immutable snapshot and returns false; use With or a VersionedTrie.
+func (t *PersistentTrie) Insert(key string) bool {
+	return false
+}
+
+// InsertAll is not supported by the immutable snapshot, which it leaves unchanged.
+func (t *PersistentTrie) InsertAll(r io.Reader) LoadReport {
+	return LoadReport{err: errReadOnly}
+}
+
+// Delete is not supported by the immutable snapshot and returns false; use Without or a VersionedTrie.
+func (t *PersistentTrie) Delete(key string) bool {
+	return false
+}
+
+// DeleteAll is not supported by the immutable snapshot, which it leaves unchanged.
+func (t *PersistentTrie) DeleteAll(r io.Reader) LoadReport {
+	return LoadReport{err: errReadOnly}
+}
+
+// walk follows s as far as the trie allows, returning the matched prefix and the node it leads to.
+func (t *PersistentTrie) walk(s string) (string, *persistentNode) {
+	currentNode := t.root
+	for i, c := range s {
+		j, found := currentNode.findEdge(c)
+		if !found {
+			return s[:i], currentNode
+		}
+		currentNode = currentNode.edges[j].node
+	}
+	return s, currentNode
+}
+
+func (t *PersistentTrie) Contains(key string) bool {
+	matched, node := t.walk(key)
+	return len(matched) == len(key) && node.isKey
+}
+
+func (t *PersistentTrie) LongestPrefix(s string) string {
+	prefix, _ := t.walk(s)
+	return prefix
+}
+
+func (t *PersistentTrie) KeysWithCommonPrefix(s string) []string {
+	prefix, node := t.walk(s)
+	if len(prefix) == 0 {
+		return []string{}
+	}
+	var keys []string
+	node.walkKeys([]byte(prefix), func(key string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func (t *PersistentTrie) Walk(prefix string, visit func(key string) bool) {
+	matched, node := t.walk(prefix)
+	if len(matched) < len(prefix) {
+		return
+	}
+	node.walkKeys([]byte(prefix), visit)
+}
+
+func (n *persistentNode) walkKeys(key []byte, visit func(key string) bool) bool {
+	if n.isKey && !visit(string(key)) {
+		return false
+	}
+	for _, edge := range n.edges {
+		if !edge.node.walkKeys(utf8.AppendRune(key, edge.c), visit) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *PersistentTrie) CountWithPrefix(prefix string) int {
+	matched, node := t.walk(prefix)
+	if len(matched) < len(prefix) {
+		return 0
+	}
+	return node.count
+}
+
+// Size returns the number of keys in the snapshot.
+func (t *PersistentTrie) Size() int {
+	return t.root.count
+}
+
+func (t *PersistentTrie) Enumerate() []string {
+	var enumeration []string
+	t.root.walkKeys([]byte{}, func(key string) bool {
+		enumeration = append(enumeration, key)
+		return true
+	})
+	return enumeration
+}
+
+func (t *PersistentTrie) String() string {
+	return strings.Join(t.Enumerate(), "\n")
+}
+
+// VersionedTrie holds the current snapshot of a PersistentTrie. Updates replace the snapshot
+// atomically, so a long-running server can hand each request the Snapshot current when it arrived
+// while words are added or a dictionary is reloaded.
+type VersionedTrie struct {
+	current atomic.Value // *PersistentTrie
+}
+
+func newVersionedTrie() *VersionedTrie {
+	v := &VersionedTrie{}
+	v.current.Store(newPersistentTrie())
+	return v
+}
+
+// Snapshot returns the current version, which later updates leave unchanged.
+func (v *VersionedTrie) Snapshot() *PersistentTrie {
+	return v.current.Load().(*PersistentTrie)
+}
+
+// update replaces the current snapshot with change applied to it, retrying if another update
+// replaced it first.
+func (v *VersionedTrie) update(change func(t *PersistentTrie) *PersistentTrie) {
+	for {
+		old := v.Snapshot()
+		if v.current.CompareAndSwap(old, change(old)) {
+			return
+		}
+	}
+}
+
+func (v *VersionedTrie) Insert(key string) bool {
+	v.update(func(t *PersistentTrie) *PersistentTrie {
+		return t.With(key)
+	})
+	return true
+}
+
+// InsertAll publishes the keys read from r as a single new version.
+func (v *VersionedTrie) InsertAll(r io.Reader) LoadReport {
+	keys, report := readWords(r)
+	v.update(func(t *PersistentTrie) *PersistentTrie {
+		for _, key := range keys {
+			t = t.With(key)
+		}
+		return t
+	})
+	return report
+}
+
+func (v *VersionedTrie) Delete(key string) bool {
+	deleted := false
+	v.update(func(t *PersistentTrie) *PersistentTrie {
+		deleted = t.Contains(key)
+		return t.Without(key)
+	})
+	return deleted
+}
+
+// DeleteAll publishes the trie without the keys read from r as a single new version.
+func (v *VersionedTrie) DeleteAll(r io.Reader) LoadReport {
+	keys, report := readWords(r)
+	v.update(func(t *PersistentTrie) *PersistentTrie {
+		for _, key := range keys {
+			t = t.Without(key)
+		}
+		return t
+	})
+	return report
+}
+
+func (v *VersionedTrie) Contains(key string) bool {
+	return v.Snapshot().Contains(key)
+}
+
+func (v *VersionedTrie) LongestPrefix(key string) string {
+	return v.Snapshot().LongestPrefix(key)
+}
+
+func (v *VersionedTrie) KeysWithCommonPrefix(prefix string) []string {
+	return v.Snapshot().KeysWithCommonPrefix(prefix)
+}
+
+// Walk walks the snapshot current when it is called, so visit may update the trie.
+func (v *VersionedTrie) Walk(prefix string, visit func(key string) bool) {
+	v.Snapshot().Walk(prefix, visit)
+}
+
+func (v *VersionedTrie) CountWithPrefix(prefix string) int {
+	return v.Snapshot().CountWithPrefix(prefix)
+}
+
+func (v *VersionedTrie) Size() int {
+	return v.Snapshot().Size()
+}
+
+func (v *VersionedTrie) Enumerate() []string {
+	return v.Snapshot().Enumerate()
+}
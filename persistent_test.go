@@ -0,0 +1,87 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPersistentTrieWith(t *testing.T) {
+	v1 := newPersistentTrie().With("the").With("them").With("zebra")
+	v2 := v1.With("these")
+	if v1.Contains("these") || v1.Size() != 3 {
+		t.Fatalf("Expected the old version to be unchanged, got %v", v1.Enumerate())
+	}
+	expected := []string{"the", "them", "these", "zebra"}
+	if actual := v2.Enumerate(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	if v2.With("these") != v2 {
+		t.Fatalf("Expected adding a present key to return the same version")
+	}
+	// only the path to "these" is copied; the zebra branch is shared
+	_, zebra1 := v1.walk("z")
+	_, zebra2 := v2.walk("z")
+	if zebra1 != zebra2 {
+		t.Fatalf("Expected unchanged branches to be shared between versions")
+	}
+}
+
+func TestPersistentTrieWithout(t *testing.T) {
+	v1 := newPersistentTrie().With("ant").With("anthem").With("bee")
+	v2 := v1.Without("anthem").Without("ant")
+	if !v1.Contains("anthem") || !v1.Contains("ant") {
+		t.Fatalf("Expected the old version to be unchanged, got %v", v1.Enumerate())
+	}
+	if actual := v2.Enumerate(); !reflect.DeepEqual(actual, []string{"bee"}) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", []string{"bee"}, actual)
+	}
+	if len(v2.root.edges) != 1 {
+		t.Fatalf("Expected empty branches to be pruned, got %d edges", len(v2.root.edges))
+	}
+	if v2.Without("ant") != v2 {
+		t.Fatalf("Expected removing an absent key to return the same version")
+	}
+	if v2.Without("bee").Size() != 0 {
+		t.Fatalf("Expected removing the last key to leave an empty trie")
+	}
+	if v1.Insert("cat") || v1.Delete("ant") || v1.Contains("cat") || !v1.Contains("ant") {
+		t.Fatalf("Expected snapshots to be read-only")
+	}
+}
+
+func TestVersionedTrieSnapshot(t *testing.T) {
+	trie := newVersionedTrie()
+	trie.InsertAll(strings.NewReader("the\nquick\nbrown\nfox"))
+	snapshot := trie.Snapshot()
+	trie.Insert("jumps")
+	trie.Delete("fox")
+	if snapshot.Contains("jumps") || !snapshot.Contains("fox") {
+		t.Fatalf("Expected an in-flight snapshot to be unchanged, got %v", snapshot.Enumerate())
+	}
+	if !trie.Contains("jumps") || trie.Contains("fox") {
+		t.Fatalf("Expected new lookups to see the update, got %v", trie.Enumerate())
+	}
+}
+
+func TestVersionedTrieConcurrentInsert(t *testing.T) {
+	trie := newVersionedTrie()
+	words := []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta", "eta", "theta"}
+	var wg sync.WaitGroup
+	for _, word := range words {
+		wg.Add(2)
+		go func(word string) {
+			defer wg.Done()
+			trie.Insert(word)
+		}(word)
+		go func(word string) {
+			defer wg.Done()
+			trie.Contains(word)
+		}(word)
+	}
+	wg.Wait()
+	if actual := trie.Size(); actual != len(words) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", len(words), actual)
+	}
+}
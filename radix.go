@@ -0,0 +1,274 @@
+package main
+
+import (
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// RadixNode is a path-compressed trie: chains of nodes with a single child are collapsed into one
+// edge labelled with the whole run of characters, so each word costs roughly one node instead of
+// one node per rune.
+type RadixNode struct {
+	isKey bool
+	edges []radixEdge
+	// count is the number of keys in the subtree rooted at this node, including its own
+	count int
+}
+
+// radixEdge leads to a child node. Edges of a node are sorted by label, and no two share a first rune.
+type radixEdge struct {
+	label string
+	node  *RadixNode
+}
+
+func newRadixNode() *RadixNode {
+	return &RadixNode{}
+}
+
+// commonPrefixLen returns the length in bytes of the longest common prefix of a and b made of whole runes.
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) {
+		ra, size := utf8.DecodeRuneInString(a[n:])
+		rb, _ := utf8.DecodeRuneInString(b[n:])
+		if ra != rb {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// findEdge returns the index of the edge starting with the first rune of s, or the index at which
+// such an edge would be inserted and false.
+func (t *RadixNode) findEdge(s string) (int, bool) {
+	_, size := utf8.DecodeRuneInString(s)
+	first := s[:size]
+	i := sort.Search(len(t.edges), func(i int) bool {
+		return t.edges[i].label >= first
+	})
+	return i, i < len(t.edges) && strings.HasPrefix(t.edges[i].label, first)
+}
+
+func (t *RadixNode) Insert(s string) bool {
+	if t.Contains(s) {
+		return true
+	}
+	currentNode := t
+	for len(s) > 0 {
+		currentNode.count++
+		i, found := currentNode.findEdge(s)
+		if !found {
+			currentNode.edges = slices.Insert(currentNode.edges, i, radixEdge{
+				label: strings.Clone(s),
+				node:  &RadixNode{isKey: true, count: 1},
+			})
+			return true
+		}
+		edge := &currentNode.edges[i]
+		n := commonPrefixLen(edge.label, s)
+		if n < len(edge.label) {
+			// split the edge where s diverges from its label
+			edge.node = &RadixNode{
+				edges: []radixEdge{{label: edge.label[n:], node: edge.node}},
+				count: edge.node.count,
+			}
+			edge.label = edge.label[:n]
+		}
+		currentNode = edge.node
+		s = s[n:]
+	}
+	currentNode.count++
+	currentNode.isKey = true
+	return currentNode.isKey
+}
+
+func (t *RadixNode) InsertAll(r io.Reader) LoadReport {
+	return readWordList(r, func(word string) {
+		_ = t.Insert(word)
+	})
+}
+
+func (t *RadixNode) Contains(key string) bool {
+	currentNode := t
+	for len(key) > 0 {
+		i, found := currentNode.findEdge(key)
+		if !found || !strings.HasPrefix(key, currentNode.edges[i].label) {
+			return false
+		}
+		key = key[len(currentNode.edges[i].label):]
+		currentNode = currentNode.edges[i].node
+	}
+	return currentNode.isKey
+}
+
+// walk follows s as far as the trie allows. It returns the number of bytes of s matched, the node
+// at or just below the point where matching stopped, and the unmatched rest of the edge label
+// leading to that node when matching stopped partway along an edge.
+func (t *RadixNode) walk(s string) (int, *RadixNode, string) {
+	matched := 0
+	currentNode := t
+	for matched < len(s) {
+		i, found := currentNode.findEdge(s[matched:])
+		if !found {
+			break
+		}
+		edge := currentNode.edges[i]
+		n := commonPrefixLen(edge.label, s[matched:])
+		matched += n
+		if n < len(edge.label) {
+			return matched, edge.node, edge.label[n:]
+		}
+		currentNode = edge.node
+	}
+	return matched, currentNode, ""
+}
+
+func (t *RadixNode) LongestPrefix(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	matched, _, _ := t.walk(s)
+	return s[:matched]
+}
+
+func (t *RadixNode) KeysWithCommonPrefix(s string) []string {
+	if len(s) == 0 {
+		return []string{}
+	}
+	matched, node, remainder := t.walk(s)
+	if matched == 0 {
+		return []string{}
+	}
+	keys := node.Enumerate()
+	slices.Sort(keys)
+	prefix := s[:matched] + remainder
+	for i := 0; i < len(keys); i++ {
+		keys[i] = prefix + keys[i]
+	}
+	return keys
+}
+
+func (t *RadixNode) Walk(prefix string, visit func(key string) bool) {
+	matched, node, remainder := t.walk(prefix)
+	if matched < len(prefix) {
+		return
+	}
+	node.walkKeys([]byte(prefix+remainder), visit)
+}
+
+func (t *RadixNode) walkKeys(key []byte, visit func(key string) bool) bool {
+	if t.isKey && !visit(string(key)) {
+		return false
+	}
+	for _, edge := range t.edges {
+		if !edge.node.walkKeys(append(key, edge.label...), visit) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *RadixNode) CountWithPrefix(prefix string) int {
+	matched, node, _ := t.walk(prefix)
+	if matched < len(prefix) {
+		return 0
+	}
+	return node.count
+}
+
+// Size returns the number of keys in the trie.
+func (t *RadixNode) Size() int {
+	return t.count
+}
+
+type radixPath struct {
+	prefix string
+	node   *RadixNode
+}
+
+func (t *RadixNode) Enumerate() []string {
+	var neighborStack = make(stack[radixPath], 0)
+	var enumeration []string
+	neighborStack.push(radixPath{"", t})
+
+	for neighborStack.size() > 0 {
+		currentPath := neighborStack.pop()
+		if currentPath.node.isKey {
+			enumeration = append(enumeration, currentPath.prefix)
+		}
+		for _, edge := range currentPath.node.edges {
+			neighborStack.push(radixPath{
+				prefix: currentPath.prefix + edge.label,
+				node:   edge.node,
+			})
+		}
+	}
+	return enumeration
+}
+
+// Delete removes key from the trie, removing its node if it has no children and merging nodes
+// left with a single child into their parent edge. It returns false if key was not present.
+func (t *RadixNode) Delete(key string) bool {
+	// the nodes and edge indexes followed from the root to reach key
+	var path []*RadixNode
+	var edges []int
+	currentNode := t
+	for len(key) > 0 {
+		i, found := currentNode.findEdge(key)
+		if !found || !strings.HasPrefix(key, currentNode.edges[i].label) {
+			return false
+		}
+		key = key[len(currentNode.edges[i].label):]
+		path = append(path, currentNode)
+		edges = append(edges, i)
+		currentNode = currentNode.edges[i].node
+	}
+	if !currentNode.isKey {
+		return false
+	}
+	currentNode.isKey = false
+	currentNode.count--
+	for _, node := range path {
+		node.count--
+	}
+	n := len(path)
+	if n == 0 {
+		return true
+	}
+	parent, parentEdge := path[n-1], edges[n-1]
+	switch len(currentNode.edges) {
+	case 0:
+		parent.edges = slices.Delete(parent.edges, parentEdge, parentEdge+1)
+		// the parent may be left as a non-key node with a single child
+		if n > 1 {
+			path[n-2].edges[edges[n-2]].merge()
+		}
+	case 1:
+		parent.edges[parentEdge].merge()
+	}
+	return true
+}
+
+// merge collapses the node at the end of edge into the edge when it is not a key and has one child.
+func (edge *radixEdge) merge() {
+	child := edge.node
+	if child.isKey || len(child.edges) != 1 {
+		return
+	}
+	edge.label = edge.label + child.edges[0].label
+	edge.node = child.edges[0].node
+}
+
+func (t *RadixNode) DeleteAll(r io.Reader) LoadReport {
+	return readWordList(r, func(word string) {
+		_ = t.Delete(word)
+	})
+}
+
+func (t *RadixNode) String() string {
+	return strings.Join(t.Enumerate(), "\n")
+}
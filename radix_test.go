@@ -0,0 +1,121 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRadixInsertContains(t *testing.T) {
+	var trie Trie = newRadixNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"ant", "anthem", "anteater", "an"}, "\n")))
+	for _, key := range []string{"ant", "anthem", "anteater", "an"} {
+		if !trie.Contains(key) {
+			t.Fatalf("Expected trie to contain %v\n", key)
+		}
+	}
+	for _, key := range []string{"a", "anth", "anteaters", "blarg", ""} {
+		if trie.Contains(key) {
+			t.Fatalf("Did not expect trie to contain key %v\n", key)
+		}
+	}
+}
+
+func TestRadixLongestPrefix(t *testing.T) {
+	trie := newRadixNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"a", "ab", "abc", "abcd", "abcdex"}, "\n")))
+	cases := map[string]string{"abcdefg": "abcde", "xyz": "", "": "", "abcd": "abcd"}
+	for s, expected := range cases {
+		actual := trie.LongestPrefix(s)
+		if actual != expected {
+			t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+		}
+	}
+}
+
+func TestRadixKeysWithPrefix(t *testing.T) {
+	trie := newRadixNode()
+	wordList := []string{"a", "abx", "abcx", "abcdx", "abcdex", "abcdey", "abcdez"}
+	trie.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+	expected := []string{"abcdex", "abcdey", "abcdez"}
+	actual := trie.KeysWithCommonPrefix("abcdefg")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	// the search stops partway along the "these" edge
+	trie = newRadixNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"these", "theses", "theseus"}, "\n")))
+	expected = []string{"these", "theses", "theseus"}
+	actual = trie.KeysWithCommonPrefix("thes")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestRadixDelete(t *testing.T) {
+	trie := newRadixNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"ant", "anthem", "anteater"}, "\n")))
+	if !trie.Delete("anthem") || trie.Delete("anthem") || trie.Delete("an") {
+		t.Fatalf("Expected anthem to be deleted once and an not to be deleted")
+	}
+	// "ant" -> {"eater"} should have merged into a single edge
+	if len(trie.edges) != 1 || trie.edges[0].label != "ant" || len(trie.edges[0].node.edges) != 1 {
+		t.Fatalf("Unexpected shape after delete: %+v", trie.edges)
+	}
+	if !trie.Delete("ant") {
+		t.Fatalf("Expected ant to be deleted")
+	}
+	if len(trie.edges) != 1 || trie.edges[0].label != "anteater" {
+		t.Fatalf("Expected a single anteater edge, got %+v", trie.edges)
+	}
+	if !trie.Delete("anteater") || len(trie.edges) != 0 {
+		t.Fatalf("Expected empty trie, got %v", trie.Enumerate())
+	}
+}
+
+// TestRadixMatchesTrieNode checks that the radix trie answers every query the same way as TrieNode.
+func TestRadixMatchesTrieNode(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	letters := []rune("abcdé")
+	randomWord := func() string {
+		word := make([]rune, 1+r.Intn(6))
+		for i := range word {
+			word[i] = letters[r.Intn(len(letters))]
+		}
+		return string(word)
+	}
+	trie := newTrieNode()
+	radix := newRadixNode()
+	for i := 0; i < 500; i++ {
+		word := randomWord()
+		trie.Insert(word)
+		radix.Insert(word)
+	}
+	for i := 0; i < 200; i++ {
+		word := randomWord()
+		if trie.Delete(word) != radix.Delete(word) {
+			t.Fatalf("Delete(%q) differs", word)
+		}
+	}
+	expected := trie.Enumerate()
+	actual := radix.Enumerate()
+	slices.Sort(expected)
+	slices.Sort(actual)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	for i := 0; i < 500; i++ {
+		word := randomWord()
+		if trie.Contains(word) != radix.Contains(word) {
+			t.Fatalf("Contains(%q) differs", word)
+		}
+		if trie.LongestPrefix(word) != radix.LongestPrefix(word) {
+			t.Fatalf("LongestPrefix(%q): expected %q, got %q", word, trie.LongestPrefix(word), radix.LongestPrefix(word))
+		}
+		if !reflect.DeepEqual(trie.KeysWithCommonPrefix(word), radix.KeysWithCommonPrefix(word)) {
+			t.Fatalf("KeysWithCommonPrefix(%q) differs", word)
+		}
+	}
+}
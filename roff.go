@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+const manMode = "man"
+
+// roffTextMacros are man(7) macros whose arguments are rendered as text, joined by spaces.
+var roffTextMacros = map[string]bool{
+	"SH": true, "SS": true, "B": true, "I": true, "SM": true, "SB": true,
+}
+
+// roffAlternatingMacros are man(7) macros that alternate fonts between arguments, which are
+// rendered without separating spaces, e.g. ".BR ls (1)" renders as "ls(1)".
+var roffAlternatingMacros = map[string]bool{
+	"BR": true, "BI": true, "IB": true, "IR": true, "RB": true, "RI": true,
+}
+
+// roffSpecialChars renders the \(xx and \[xx] escapes that stand for punctuation;
+// other named glyphs render as a space.
+var roffSpecialChars = map[string]string{
+	"aq": "'", "cq": "'", "oq": "'", "dq": "\"", "lq": "\"", "rq": "\"",
+	"em": " ", "en": "-", "hy": "-", "bu": " ", "co": " ",
+}
+
+// roffArgs splits a request line's arguments, honoring double-quoted arguments.
+func roffArgs(s string) []string {
+	var args []string
+	var current strings.Builder
+	inQuotes, inArg := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' && inQuotes && i+1 < len(s) && s[i+1] == '"':
+			current.WriteByte('"')
+			i++
+		case c == '"' && !inArg:
+			inQuotes, inArg = true, true
+		case c == '"' && inQuotes:
+			inQuotes = false
+		case (c == ' ' || c == '\t') && !inQuotes:
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// skipDelimited returns the index just past a delimited escape argument such as 'text' or [name].
+func skipDelimited(s string, i int) int {
+	if i >= len(s) {
+		return i
+	}
+	closing := s[i]
+	if closing == '[' {
+		closing = ']'
+	}
+	end := strings.IndexByte(s[i+1:], closing)
+	if end < 0 {
+		return len(s)
+	}
+	return i + 1 + end + 1
+}
+
+// skipEscapeName returns the name of a font, string or register escape argument and the index after it,
+// which is a single character, two characters after '(' or a bracketed name.
+func skipEscapeName(s string, i int) (string, int) {
+	if i >= len(s) {
+		return "", i
+	}
+	switch s[i] {
+	case '(':
+		if i+3 > len(s) {
+			return s[i+1:], len(s)
+		}
+		return s[i+1 : i+3], i + 3
+	case '[':
+		end := skipDelimited(s, i)
+		return strings.TrimSuffix(s[i+1:end], "]"), end
+	}
+	return s[i : i+1], i + 1
+}
+
+// renderRoff renders the escapes in a line of roff text, dropping font changes, comments and
+// other formatting so only the visible words remain.
+func renderRoff(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			break
+		}
+		switch s[i] {
+		case '"', '#':
+			return b.String()
+		case '\\', 'e':
+			b.WriteByte('\\')
+		case '-':
+			b.WriteByte('-')
+		case ' ', '~', '0':
+			b.WriteByte(' ')
+		case '(', '[':
+			name, end := skipEscapeName(s, i)
+			if special, ok := roffSpecialChars[name]; ok {
+				b.WriteString(special)
+			} else {
+				b.WriteByte(' ')
+			}
+			i = end - 1
+		case 'f', '*', 'n', 'g', 'k', 'F', 'm', 'M', 'Y':
+			_, end := skipEscapeName(s, i+1)
+			i = end - 1
+		case 's':
+			j := i + 1
+			if j < len(s) && (s[j] == '+' || s[j] == '-') {
+				j++
+			}
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			i = j - 1
+		case 'w', 'h', 'v', 'l', 'L', 'o', 'b', 'x', 'X', 'D', 'N', 'R', 'A', 'B', 'Z', 'C':
+			i = skipDelimited(s, i+1) - 1
+		}
+		// any other escape, such as \&, \|, \^ or \c, renders as nothing
+	}
+	return b.String()
+}
+
+// extractRoff reads a roff document such as a man page, checking the rendered text of text lines
+// and of macros like .SH and .B. Example blocks between .EX and .EE are skipped. Errors are reported
+// on the source line they came from.
+func extractRoff(r io.Reader) ([]Segment, error) {
+	var segments []Segment
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	skipUntilDots := false
+	inExample := false
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if skipUntilDots {
+			skipUntilDots = strings.TrimSpace(line) != ".."
+			continue
+		}
+		if !strings.HasPrefix(line, ".") && !strings.HasPrefix(line, "'") {
+			if inExample {
+				continue
+			}
+			segments = append(segments, Segment{text: renderRoff(line), line: lineNum})
+			continue
+		}
+		request := strings.TrimLeft(line[1:], " \t")
+		name := request
+		args := ""
+		if i := strings.IndexAny(request, " \t"); i >= 0 {
+			name, args = request[:i], request[i+1:]
+		}
+		var text string
+		switch {
+		case name == "de" || name == "de1" || name == "am" || name == "ig":
+			skipUntilDots = true
+		case name == "EX" || name == "EE":
+			inExample = name == "EX"
+		case name == "IP":
+			// only the tag is text; the second argument of .IP is an indentation
+			if ipArgs := roffArgs(args); len(ipArgs) > 0 {
+				text = ipArgs[0]
+			}
+		case roffTextMacros[name]:
+			text = strings.Join(roffArgs(args), " ")
+		case roffAlternatingMacros[name]:
+			text = strings.Join(roffArgs(args), "")
+		}
+		if text != "" {
+			segments = append(segments, Segment{text: renderRoff(text), line: lineNum})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderRoff(t *testing.T) {
+	cases := map[string]string{
+		`\fBbold\fR and \fIitalic\fP`:      "bold and italic",
+		`use \-\-verbose \(em or not`:      "use --verbose   or not",
+		`don\(aqt \*(Lxstop\&.`:            "don't stop.",
+		`text \" a comment`:                "text ",
+		`\s-2small\s0 \f[CB]font\f[]`:      "small font",
+		`width \w'abc' gone\c`:             "width  gone",
+		`back\\slash and non\ breaking`:    `back\slash and non breaking`,
+		`\fB\-o\fR, \fB\-\-output\fR=FILE`: "-o, --output=FILE",
+	}
+	for input, expected := range cases {
+		actual := renderRoff(input)
+		if actual != expected {
+			t.Fatalf("renderRoff(%q)\nExpected:\t%q\nActual:\t\t%q\n", input, expected, actual)
+		}
+	}
+}
+
+func TestRoffArgs(t *testing.T) {
+	expected := []string{"NAME", "two words", `say "hi"`}
+	actual := roffArgs(`NAME "two words" "say ""hi"""`)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestExtractRoff(t *testing.T) {
+	page := strings.Join([]string{
+		`.\" Manual page for tool`,
+		`.TH TOOL 1 "2024-01-01" "tool 1.0"`,
+		`.SH "SEE ALSO"`,
+		`.de XX`,
+		`ignored macro defnition`,
+		`..`,
+		`The \fBtool\fR command`,
+		`.BR ls (1)`,
+		`.IP \(bu 4`,
+		`.EX`,
+		`tool \-x fle`,
+		`.EE`,
+		`.PP`,
+	}, "\n")
+	segments, err := extractRoff(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual []string
+	var lines []int
+	for _, segment := range segments {
+		actual = append(actual, segment.text)
+		lines = append(lines, segment.line)
+	}
+	expected := []string{"SEE ALSO", "The tool command", "ls(1)", " "}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%q\nActual:\t\t%q\n", expected, actual)
+	}
+	expectedLines := []int{3, 7, 8, 9}
+	if !reflect.DeepEqual(lines, expectedLines) {
+		t.Fatalf("\nExpected lines:\t%v\nActual lines:\t%v\n", expectedLines, lines)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import "slices"
+
+// sortedKeys returns the keys of trie that satisfy keep, in lexicographic order.
+func sortedKeys(trie Trie, keep func(key string) bool) []string {
+	var keys []string
+	trie.Walk("", func(key string) bool {
+		if keep(key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	return keys
+}
+
+// buildTrie creates a trie with newTrie holding keys. Keys are added as they are rather than as a
+// word list, which would strip comments and spaces from them, and a DAWG is built from them in one pass.
+func buildTrie(newTrie func() Trie, keys []string) Trie {
+	trie := newTrie()
+	if _, ok := trie.(*DawgNode); ok {
+		return buildDawg(keys)
+	}
+	for _, key := range keys {
+		_ = trie.Insert(key)
+	}
+	return trie
+}
+
+// Union returns a new trie, created by newTrie, holding the keys that are in a or b.
+func Union(a, b Trie, newTrie func() Trie) Trie {
+	all := func(key string) bool { return true }
+	keys := append(sortedKeys(a, all), sortedKeys(b, all)...)
+	slices.Sort(keys)
+	return buildTrie(newTrie, slices.Compact(keys))
+}
+
+// Intersect returns a new trie, created by newTrie, holding the keys that are in both a and b.
+func Intersect(a, b Trie, newTrie func() Trie) Trie {
+	if b.Size() < a.Size() {
+		a, b = b, a
+	}
+	return buildTrie(newTrie, sortedKeys(a, b.Contains))
+}
+
+// Difference returns a new trie, created by newTrie, holding the keys that are in a but not in b.
+func Difference(a, b Trie, newTrie func() Trie) Trie {
+	return buildTrie(newTrie, sortedKeys(a, func(key string) bool {
+		return !b.Contains(key)
+	}))
+}
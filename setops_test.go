@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSetOperations(t *testing.T) {
+	base := []string{"the", "them", "these", "zebra"}
+	team := []string{"the", "theses", "gospellcheck", "zebra"}
+	for name, newTrie := range trieImplementations {
+		a := newTrie()
+		a.InsertAll(strings.NewReader(strings.Join(team, "\n")))
+		b := newTrie()
+		b.InsertAll(strings.NewReader(strings.Join(base, "\n")))
+
+		tests := []struct {
+			operation string
+			result    Trie
+			expected  []string
+		}{
+			{"union", Union(a, b, newTrie), []string{"gospellcheck", "the", "them", "these", "theses", "zebra"}},
+			{"intersect", Intersect(a, b, newTrie), []string{"the", "zebra"}},
+			{"difference", Difference(a, b, newTrie), []string{"gospellcheck", "theses"}},
+		}
+		for _, test := range tests {
+			actual := sortedKeys(test.result, func(string) bool { return true })
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("%s %s\nExpected:\t%v\nActual:\t\t%v\n", name, test.operation, test.expected, actual)
+			}
+		}
+		if a.Size() != len(team) || b.Size() != len(base) {
+			t.Fatalf("%s: expected the operands to be unchanged", name)
+		}
+	}
+}
+
+func TestDifference_Empty(t *testing.T) {
+	a := newTrieNode()
+	a.InsertAll(strings.NewReader("the\nthem"))
+	result := Difference(a, a, func() Trie { return newDawgNode() })
+	if result.Size() != 0 || result.Contains("") {
+		t.Fatalf("Expected an empty trie, got %v", result.Enumerate())
+	}
+}
+
+func TestSetOperations_KeysKeptVerbatim(t *testing.T) {
+	keys := []string{" padded", "c#", "f# minor", "the"}
+	for name, newTrie := range trieImplementations {
+		a := newTrie()
+		b := newTrie()
+		if name == "dawg" {
+			a, b = buildDawg(slices.Clone(keys)), buildDawg([]string{"the"})
+		} else {
+			for _, key := range keys {
+				a.Insert(key)
+			}
+			b.Insert("the")
+		}
+		actual := sortedKeys(Difference(a, b, newTrie), func(string) bool { return true })
+		expected := []string{" padded", "c#", "f# minor"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("%s\nExpected:\t%q\nActual:\t\t%q\n", name, expected, actual)
+		}
+	}
+}
@@ -6,57 +6,100 @@ import (
 	"io"
 	"log"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
+	"unicode/utf8"
 )
 
 type SpellingError struct {
 	misspelled   string
 	line         int
+	location     string
 	sentence     int
 	wordPosition int
+	offset       int
+	cell         bool
 	suggestions  []string
 }
+
+// Segment is a unit of text to spellcheck, such as a line of a text file or a
+// paragraph of a document. line orders segments within the input; location, if
+// set, describes where the segment was found in place of its line number. Errors
+// in cell segments are reported by character offset rather than sentence and word.
+type Segment struct {
+	text     string
+	line     int
+	location string
+	cell     bool
+}
+
 type Spellcheck interface {
-	InitializeWordList(r io.Reader)
+	InitializeWordList(r io.Reader) LoadReport
 	CheckReader(r io.Reader) chan SpellingError
+	CheckSegments(segments <-chan Segment) chan SpellingError
 	GetSuggestions(word string) []string
 }
 
 type TrieSpellcheck struct {
 	trie         Trie
+	newTrie      func() Trie
 	nSuggestions int
 }
 
 func newSpellcheck(nSuggestions int) Spellcheck {
+	return newSpellcheckWithTrie(nSuggestions, func() Trie { return newTrieNode() })
+}
+
+// newSpellcheckWithTrie creates a Spellcheck whose dictionary is built in a Trie created by newTrie.
+func newSpellcheckWithTrie(nSuggestions int, newTrie func() Trie) Spellcheck {
+	return &TrieSpellcheck{
+		newTrie:      newTrie,
+		nSuggestions: nSuggestions,
+	}
+}
 
+// newSpellcheckFromTrie creates a Spellcheck using an already populated dictionary.
+func newSpellcheckFromTrie(nSuggestions int, trie Trie) Spellcheck {
 	return &TrieSpellcheck{
+		trie:         trie,
+		newTrie:      func() Trie { return trie },
 		nSuggestions: nSuggestions,
 	}
 }
 
-func (spellcheck *TrieSpellcheck) InitializeWordList(r io.Reader) {
-	spellcheck.trie = newTrieNode()
-	spellcheck.trie.InsertAll(r)
+func (spellcheck *TrieSpellcheck) InitializeWordList(r io.Reader) LoadReport {
+	spellcheck.trie = spellcheck.newTrie()
+	return insertWordList(spellcheck.trie, r)
 }
 
 func (spellcheck *TrieSpellcheck) CheckReader(r io.Reader) chan SpellingError {
-	linesChan := make(chan string)
+	linesChan := make(chan Segment)
 	scanner := bufio.NewScanner(r)
 	go func() {
 		defer close(linesChan)
+		i := 0
 		for scanner.Scan() {
-			line := scanner.Text()
-			linesChan <- line
+			i++
+			linesChan <- Segment{text: scanner.Text(), line: i}
 		}
 	}()
 
-	errChan := spellcheck.checkLines(linesChan)
+	errChan := spellcheck.CheckSegments(linesChan)
 	return errChan
 }
 
 func (se SpellingError) String() string {
-	s := fmt.Sprintf("Line %d, sentence %d, word %d: '%s'", se.line, se.sentence, se.wordPosition, se.misspelled)
+	location := se.location
+	if location == "" {
+		location = fmt.Sprintf("Line %d", se.line)
+	}
+	var s string
+	if se.cell {
+		s = fmt.Sprintf("%s, offset %d: '%s'", location, se.offset, se.misspelled)
+	} else {
+		s = fmt.Sprintf("%s, sentence %d, word %d: '%s'", location, se.sentence, se.wordPosition, se.misspelled)
+	}
 	if len(se.suggestions) > 0 {
 		s = s + fmt.Sprintf("\n\tSuggestions: %v", se.suggestions)
 	}
@@ -71,19 +114,46 @@ func normalizeWord(word string) string {
 	normalizedBytes := re.ReplaceAll([]byte(strings.ToLower(word)), []byte(""))
 	return string(normalizedBytes)
 }
+
+// GetSuggestions returns anagrams of word, which catch transposed letters, followed by the first
+// words in lexicographic order that share the longest prefix of word found in the dictionary.
 func (spellcheck *TrieSpellcheck) GetSuggestions(word string) []string {
-	suggestions := spellcheck.trie.KeysWithCommonPrefix(word)
-	if len(suggestions) > spellcheck.nSuggestions {
-		return suggestions[:spellcheck.nSuggestions]
-	} else {
+	suggestions := []string{}
+	if spellcheck.nSuggestions <= 0 {
 		return suggestions
 	}
+	if finder, ok := spellcheck.trie.(anagramFinder); ok {
+		for _, anagram := range finder.Anagrams(word, false) {
+			if len(suggestions) < spellcheck.nSuggestions && anagram != word {
+				suggestions = append(suggestions, anagram)
+			}
+		}
+	}
+	prefix := spellcheck.trie.LongestPrefix(word)
+	if len(prefix) == 0 || len(suggestions) == spellcheck.nSuggestions {
+		return suggestions
+	}
+	anagrams := len(suggestions)
+	spellcheck.trie.Walk(prefix, func(key string) bool {
+		if !slices.Contains(suggestions[:anagrams], key) {
+			suggestions = append(suggestions, key)
+		}
+		return len(suggestions) < spellcheck.nSuggestions
+	})
+	return suggestions
 }
 
-func (spellcheck *TrieSpellcheck) checkLine(line string, lineNum int, out chan<- SpellingError, wg *sync.WaitGroup) {
-	sentences := strings.FieldsFunc(line, func(r rune) bool {
+func (spellcheck *TrieSpellcheck) checkLine(segment Segment, out chan<- SpellingError, wg *sync.WaitGroup) {
+	sentences := strings.FieldsFunc(segment.text, func(r rune) bool {
 		return r == '.' || r == '!' || r == '?'
 	})
+	// byte offset of each sentence within the segment, so misspellings can be located in cells
+	sentenceStarts := make([]int, len(sentences))
+	cursor := 0
+	for s, sentence := range sentences {
+		sentenceStarts[s] = cursor + strings.Index(segment.text[cursor:], sentence)
+		cursor = sentenceStarts[s] + len(sentence)
+	}
 	var sentenceWg sync.WaitGroup
 	for s, sentence := range sentences {
 		sentenceWg.Add(1)
@@ -94,21 +164,26 @@ func (spellcheck *TrieSpellcheck) checkLine(line string, lineNum int, out chan<-
 				return
 			}
 			words := strings.Split(trimmedSentence, " ")
+			wordStart := sentenceStarts[sentenceNum] + len(sentence) - len(strings.TrimLeft(sentence, ". "))
 
 			for w, word := range words {
 				normalized := normalizeWord(word)
 				if len(normalized) > 0 && !spellcheck.trie.Contains(normalized) {
 					spellingError := SpellingError{
 						misspelled:   word,
-						line:         lineNum,
+						line:         segment.line,
+						location:     segment.location,
+						cell:         segment.cell,
 						sentence:     sentenceNum + 1,
 						wordPosition: w + 1,
+						offset:       utf8.RuneCountInString(segment.text[:wordStart]) + 1,
 					}
 					if spellcheck.nSuggestions > 0 {
 						spellingError.suggestions = spellcheck.GetSuggestions(normalized)
 					}
 					out <- spellingError
 				}
+				wordStart += len(word) + 1
 			}
 		}(s, sentence)
 	}
@@ -116,14 +191,12 @@ func (spellcheck *TrieSpellcheck) checkLine(line string, lineNum int, out chan<-
 	wg.Done()
 }
 
-func (spellcheck *TrieSpellcheck) checkLines(lines <-chan string) chan SpellingError {
+func (spellcheck *TrieSpellcheck) CheckSegments(segments <-chan Segment) chan SpellingError {
 	errChan := make(chan SpellingError)
 	var wg sync.WaitGroup
-	i := 0
-	for line := range lines {
+	for segment := range segments {
 		wg.Add(1)
-		go spellcheck.checkLine(line, i+1, errChan, &wg)
-		i++
+		go spellcheck.checkLine(segment, errChan, &wg)
 	}
 	go func() {
 		defer close(errChan)
@@ -71,3 +71,46 @@ func TestSuggestions2(t *testing.T) {
 		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
 	}
 }
+
+func TestCheckSegments(t *testing.T) {
+	wordList := []string{"abc", "def"}
+	spellcheck := newSpellcheck(0)
+	spellcheck.InitializeWordList(strings.NewReader(strings.Join(wordList, "\n")))
+	segments := []Segment{
+		{text: "abc def", line: 1, location: "Paragraph 1"},
+		{text: "Def. Abc xyz", line: 2, location: "Paragraph 2"},
+	}
+	spellingErrors := chanToSlice(spellcheck.CheckSegments(sliceToChan(segments)))
+	if len(spellingErrors) != 1 {
+		t.Fatalf("\nExpected 1 spelling error; got %v\n", spellingErrors)
+	}
+	expected := "Paragraph 2, sentence 2, word 2: 'xyz'"
+	if actual := spellingErrors[0].String(); actual != expected {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestCheckReaderWithDawg(t *testing.T) {
+	wordList := []string{"abc", "def", "ghi", "jkl"}
+	newTrie, err := getTrieImplementation("dawg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spellcheck := newSpellcheckWithTrie(1, newTrie)
+	spellcheck.InitializeWordList(strings.NewReader(strings.Join(wordList, "\n")))
+	spellingErrors := chanToSlice(spellcheck.CheckReader(strings.NewReader("Abc xxx DEF. \ngHi jkl zzz")))
+	if len(spellingErrors) != 2 {
+		t.Fatalf("\nExpected 2 spelling errors; got %v\n", spellingErrors)
+	}
+}
+
+func TestSuggestionsAnagrams(t *testing.T) {
+	spellcheck := newSpellcheck(3)
+	wordList := []string{"the", "then", "there", "hte"}
+	spellcheck.InitializeWordList(strings.NewReader(strings.Join(wordList, "\n")))
+	expected := []string{"hte", "the", "then"}
+	actual := spellcheck.GetSuggestions("teh")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
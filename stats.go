@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// Go maps have no fixed per-entry size, so a map of children is estimated from its header and
+// an entry of a rune key and pointer value with room left by the load factor.
+const (
+	mapHeaderBytes = 48
+	mapEntryBytes  = 24
+)
+
+// TrieStats describes the shape and memory use of a dictionary. Nodes shared in a DAWG are
+// counted once.
+type TrieStats struct {
+	keys  int
+	nodes int
+	// inner is the number of nodes with at least one edge
+	inner int
+	edges int
+	// maxDepth is the length in runes of the longest key
+	maxDepth int
+	// bytes estimates the memory held by the nodes and edges
+	bytes int
+	// runes counts the runes labelling edges
+	runes map[rune]int
+}
+
+// statsReporter is implemented by dictionaries that can describe their shape.
+type statsReporter interface {
+	Stats() TrieStats
+}
+
+func newTrieStats(keys int) TrieStats {
+	return TrieStats{keys: keys, runes: make(map[rune]int)}
+}
+
+func (stats *TrieStats) addNode(edges int) {
+	stats.nodes++
+	stats.edges += edges
+	if edges > 0 {
+		stats.inner++
+	}
+}
+
+// branchingFactor returns the average number of edges of the nodes that have any.
+func (stats TrieStats) branchingFactor() float64 {
+	if stats.inner == 0 {
+		return 0
+	}
+	return float64(stats.edges) / float64(stats.inner)
+}
+
+// write prints the statistics, with the runes from most to least frequent.
+func (stats TrieStats) write(w io.Writer) {
+	fmt.Fprintf(w, "keys\t\t\t%d\n", stats.keys)
+	fmt.Fprintf(w, "nodes\t\t\t%d\n", stats.nodes)
+	fmt.Fprintf(w, "edges\t\t\t%d\n", stats.edges)
+	fmt.Fprintf(w, "max depth\t\t%d\n", stats.maxDepth)
+	fmt.Fprintf(w, "branching factor\t%.2f\n", stats.branchingFactor())
+	fmt.Fprintf(w, "estimated bytes\t\t%d\n", stats.bytes)
+	runes := make([]rune, 0, len(stats.runes))
+	for c := range stats.runes {
+		runes = append(runes, c)
+	}
+	slices.SortFunc(runes, func(a, b rune) int {
+		if stats.runes[a] != stats.runes[b] {
+			return stats.runes[b] - stats.runes[a]
+		}
+		return int(a - b)
+	})
+	fmt.Fprintf(w, "runes\n")
+	for _, c := range runes {
+		fmt.Fprintf(w, "\t%q\t\t%d\n", c, stats.runes[c])
+	}
+}
+
+func (t *ValueTrie[V]) Stats() TrieStats {
+	stats := newTrieStats(t.Size())
+	var visit func(node *ValueTrie[V], depth int)
+	visit = func(node *ValueTrie[V], depth int) {
+		stats.addNode(len(node.children))
+		if node.isKey && depth > stats.maxDepth {
+			stats.maxDepth = depth
+		}
+		for c, child := range node.children {
+			stats.runes[c]++
+			visit(child, depth+1)
+		}
+	}
+	visit(t, 0)
+	stats.bytes = stats.nodes*(int(unsafe.Sizeof(*t))+mapHeaderBytes) + stats.edges*mapEntryBytes
+	return stats
+}
+
+func (t *RadixNode) Stats() TrieStats {
+	stats := newTrieStats(t.Size())
+	labelBytes := 0
+	var visit func(node *RadixNode, depth int)
+	visit = func(node *RadixNode, depth int) {
+		stats.addNode(len(node.edges))
+		if node.isKey && depth > stats.maxDepth {
+			stats.maxDepth = depth
+		}
+		for _, edge := range node.edges {
+			for _, c := range edge.label {
+				stats.runes[c]++
+			}
+			labelBytes += len(edge.label)
+			visit(edge.node, depth+utf8.RuneCountInString(edge.label))
+		}
+	}
+	visit(t, 0)
+	stats.bytes = stats.nodes*int(unsafe.Sizeof(*t)) + stats.edges*int(unsafe.Sizeof(radixEdge{})) + labelBytes
+	return stats
+}
+
+// Stats counts each node once however many edges lead to it. Every path of a minimal DAWG ends
+// in a key, so the longest path is the longest key.
+func (t *DawgNode) Stats() TrieStats {
+	stats := newTrieStats(t.Size())
+	heights := make(map[*DawgNode]int)
+	var visit func(node *DawgNode) int
+	visit = func(node *DawgNode) int {
+		if height, visited := heights[node]; visited {
+			return height
+		}
+		stats.addNode(len(node.edges))
+		height := 0
+		for _, edge := range node.edges {
+			stats.runes[edge.c]++
+			if h := visit(edge.node) + 1; h > height {
+				height = h
+			}
+		}
+		heights[node] = height
+		return height
+	}
+	stats.maxDepth = visit(t)
+	stats.bytes = stats.nodes*int(unsafe.Sizeof(*t)) + stats.edges*int(unsafe.Sizeof(dawgEdge{}))
+	return stats
+}
+
+// Stats reads every node of the compiled dictionary, whose size is exactly that of the mapping.
+func (d *MappedDictionary) Stats() TrieStats {
+	stats := newTrieStats(d.Size())
+	// nodes are in topological order, so counting backwards finds each node's children's heights first
+	heights := make([]int, d.nodeCount)
+	for i := int(d.nodeCount) - 1; i >= 0; i-- {
+		firstEdge, count, _, _ := d.node(uint32(i))
+		stats.addNode(int(count))
+		for j := firstEdge; j < firstEdge+count; j++ {
+			c, target := d.edge(j)
+			stats.runes[c]++
+			if target > uint32(i) && target < d.nodeCount && heights[target]+1 > heights[i] {
+				heights[i] = heights[target] + 1
+			}
+		}
+	}
+	stats.maxDepth = heights[0]
+	stats.bytes = headerSize + len(d.nodes) + len(d.edges) + trailerSize
+	return stats
+}
+
+func (t *PersistentTrie) Stats() TrieStats {
+	stats := newTrieStats(t.Size())
+	var visit func(node *persistentNode, depth int)
+	visit = func(node *persistentNode, depth int) {
+		stats.addNode(len(node.edges))
+		if node.isKey && depth > stats.maxDepth {
+			stats.maxDepth = depth
+		}
+		for _, edge := range node.edges {
+			stats.runes[edge.c]++
+			visit(edge.node, depth+1)
+		}
+	}
+	visit(t.root, 0)
+	stats.bytes = stats.nodes*int(unsafe.Sizeof(*t.root)) + stats.edges*int(unsafe.Sizeof(persistentEdge{}))
+	return stats
+}
+
+func (v *VersionedTrie) Stats() TrieStats {
+	return v.Snapshot().Stats()
+}
+
+// Stats describes the wrapped trie, or reports only its size if it can't describe itself.
+func (t *SyncTrie) Stats() TrieStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if reporter, ok := t.trie.(statsReporter); ok {
+		return reporter.Stats()
+	}
+	return newTrieStats(t.trie.Size())
+}
+
+// Stats describes the wrapped trie, adding the filter to its estimated bytes.
+func (t *FilteredTrie) Stats() TrieStats {
+	stats := newTrieStats(t.Trie.Size())
+	if reporter, ok := t.Trie.(statsReporter); ok {
+		stats = reporter.Stats()
+	}
+	stats.bytes += len(t.filter.bits) * 8
+	return stats
+}
+
+// Stats reports the size of the filter, which has no nodes.
+func (t *MembershipFilter) Stats() TrieStats {
+	stats := newTrieStats(t.size)
+	stats.bytes = len(t.filter.bits) * 8
+	return stats
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	wordList := []string{"walking", "talking", "walked", "talked", "naïve", "a"}
+	tests := map[string]struct {
+		nodes, edges int
+	}{
+		// one node per distinct prefix, including the root
+		"trie": {25, 24},
+		// the root, "a", "n..." and "t..."/"w..." split at "alk" then "ed"/"ing"
+		"radix": {9, 8},
+		// "-ing" and "-ed" are shared between walk and talk
+		"dawg":       {13, 16},
+		"persistent": {25, 24},
+	}
+	for name, expected := range tests {
+		trie := trieImplementations[name]()
+		trie.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+		stats := trie.(statsReporter).Stats()
+		if stats.keys != 6 || stats.maxDepth != 7 {
+			t.Fatalf("%s: expected 6 keys up to 7 runes long, got %+v", name, stats)
+		}
+		if stats.nodes != expected.nodes || stats.edges != expected.edges {
+			t.Fatalf("%s\nExpected:\t%v nodes, %v edges\nActual:\t\t%v nodes, %v edges\n",
+				name, expected.nodes, expected.edges, stats.nodes, stats.edges)
+		}
+		if stats.runes['ï'] != 1 || stats.bytes <= 0 {
+			t.Fatalf("%s: expected rune counts and a size estimate, got %+v", name, stats)
+		}
+	}
+}
+
+func TestMappedDictionaryStats(t *testing.T) {
+	words := []string{"walking", "talking", "walked", "talked", "naïve", "a"}
+	data := compileWords(t, words)
+	expected := newDawgNode()
+	expected.InsertAll(strings.NewReader(strings.Join(words, "\n")))
+	actual := mapWords(t, data).Stats()
+	dawgStats := expected.Stats()
+	if actual.nodes != dawgStats.nodes || actual.edges != dawgStats.edges || actual.maxDepth != dawgStats.maxDepth {
+		t.Fatalf("\nExpected:\t%+v\nActual:\t\t%+v\n", dawgStats, actual)
+	}
+	if actual.bytes != len(data) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", len(data), actual.bytes)
+	}
+}
+
+func TestStatsWrite(t *testing.T) {
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader("aa\nab\nac"))
+	var buf bytes.Buffer
+	trie.Stats().write(&buf)
+	output := buf.String()
+	for _, expected := range []string{"keys\t\t\t3\n", "branching factor\t2.00\n", "\t'a'\t\t2\n"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected %q in\n%v", expected, output)
+		}
+	}
+	// runes are listed from most to least frequent
+	if strings.Index(output, "'a'") > strings.Index(output, "'b'") {
+		t.Fatalf("Expected the most frequent rune first in\n%v", output)
+	}
+}
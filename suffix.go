@@ -0,0 +1,91 @@
+package main
+
+import (
+	"io"
+	"slices"
+	"strings"
+)
+
+// SuffixIndexedTrie is a Trie that also indexes its keys by ending, keeping a second trie of the
+// reversed keys in step with the forward one, for rhyme lookups and matching inflections.
+type SuffixIndexedTrie struct {
+	forward *TrieNode
+	reverse *TrieNode
+}
+
+func newSuffixIndexedTrie() *SuffixIndexedTrie {
+	return &SuffixIndexedTrie{
+		forward: newTrieNode(),
+		reverse: newTrieNode(),
+	}
+}
+
+func (t *SuffixIndexedTrie) Insert(key string) bool {
+	t.reverse.Insert(reverseString(key))
+	return t.forward.Insert(key)
+}
+
+func (t *SuffixIndexedTrie) InsertAll(r io.Reader) LoadReport {
+	return readWordList(r, func(word string) {
+		_ = t.Insert(word)
+	})
+}
+
+func (t *SuffixIndexedTrie) Delete(key string) bool {
+	t.reverse.Delete(reverseString(key))
+	return t.forward.Delete(key)
+}
+
+func (t *SuffixIndexedTrie) DeleteAll(r io.Reader) LoadReport {
+	return readWordList(r, func(word string) {
+		_ = t.Delete(word)
+	})
+}
+
+func (t *SuffixIndexedTrie) Contains(key string) bool {
+	return t.forward.Contains(key)
+}
+
+func (t *SuffixIndexedTrie) LongestPrefix(s string) string {
+	return t.forward.LongestPrefix(s)
+}
+
+func (t *SuffixIndexedTrie) KeysWithCommonPrefix(prefix string) []string {
+	return t.forward.KeysWithCommonPrefix(prefix)
+}
+
+func (t *SuffixIndexedTrie) Walk(prefix string, visit func(key string) bool) {
+	t.forward.Walk(prefix, visit)
+}
+
+func (t *SuffixIndexedTrie) CountWithPrefix(prefix string) int {
+	return t.forward.CountWithPrefix(prefix)
+}
+
+func (t *SuffixIndexedTrie) Size() int {
+	return t.forward.Size()
+}
+
+func (t *SuffixIndexedTrie) Enumerate() []string {
+	return t.forward.Enumerate()
+}
+
+// LongestSuffix returns the longest ending of s shared with some key, the counterpart of LongestPrefix.
+func (t *SuffixIndexedTrie) LongestSuffix(s string) string {
+	return reverseString(t.reverse.LongestPrefix(reverseString(s)))
+}
+
+// KeysWithCommonSuffix returns, in lexicographic order, the keys ending with the longest suffix of s
+// shared with any key, the counterpart of KeysWithCommonPrefix.
+func (t *SuffixIndexedTrie) KeysWithCommonSuffix(s string) []string {
+	keys := t.reverse.KeysWithCommonPrefix(reverseString(s))
+	for i := 0; i < len(keys); i++ {
+		keys[i] = reverseString(keys[i])
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (t *SuffixIndexedTrie) String() string {
+	return strings.Join(t.Enumerate(), "\n")
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeysWithCommonSuffix(t *testing.T) {
+	var trie Trie = newSuffixIndexedTrie()
+	wordList := []string{"nation", "station", "ration", "fraction", "walked", "talked", "naïve"}
+	trie.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+	suffixTrie := trie.(*SuffixIndexedTrie)
+
+	expected := []string{"nation", "ration", "station"}
+	actual := suffixTrie.KeysWithCommonSuffix("creation")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	expected = []string{"talked", "walked"}
+	actual = suffixTrie.KeysWithCommonSuffix("ed")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+	if actual := suffixTrie.KeysWithCommonSuffix("xyz"); len(actual) > 0 {
+		t.Fatalf("\nExpected empty result, got %v\n", actual)
+	}
+}
+
+func TestLongestSuffix(t *testing.T) {
+	trie := newSuffixIndexedTrie()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"nation", "station", "naïve"}, "\n")))
+	cases := map[string]string{"creation": "ation", "olive": "ve", "faïve": "aïve", "": "", "zzz": ""}
+	for s, expected := range cases {
+		if actual := trie.LongestSuffix(s); actual != expected {
+			t.Fatalf("LongestSuffix(%q)\nExpected:\t%v\nActual:\t\t%v\n", s, expected, actual)
+		}
+	}
+}
+
+func TestSuffixIndexedTrieDelete(t *testing.T) {
+	trie := newSuffixIndexedTrie()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"walked", "talked"}, "\n")))
+	if !trie.Delete("walked") || trie.Contains("walked") {
+		t.Fatalf("Expected walked to be deleted")
+	}
+	expected := []string{"talked"}
+	actual := trie.KeysWithCommonSuffix("ed")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"io"
+	"sync"
+)
+
+// SyncTrie makes a Trie safe for concurrent use, so words can be added at runtime while lines are
+// being checked. Lookups share a read lock; Insert and Delete take the write lock.
+type SyncTrie struct {
+	mu   sync.RWMutex
+	trie Trie
+}
+
+func newSyncTrie(trie Trie) *SyncTrie {
+	return &SyncTrie{trie: trie}
+}
+
+func (t *SyncTrie) Insert(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.trie.Insert(key)
+}
+
+// InsertAll reads the whole list from r before taking the write lock, so a slow reader doesn't
+// block lookups, and lookups never see a partially loaded list.
+func (t *SyncTrie) InsertAll(r io.Reader) LoadReport {
+	keys, report := readWords(r)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range keys {
+		_ = t.trie.Insert(key)
+	}
+	return report
+}
+
+func (t *SyncTrie) Delete(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.trie.Delete(key)
+}
+
+// DeleteAll, like InsertAll, reads the whole list from r before taking the write lock.
+func (t *SyncTrie) DeleteAll(r io.Reader) LoadReport {
+	keys, report := readWords(r)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range keys {
+		_ = t.trie.Delete(key)
+	}
+	return report
+}
+
+func (t *SyncTrie) Contains(key string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.trie.Contains(key)
+}
+
+func (t *SyncTrie) LongestPrefix(key string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.trie.LongestPrefix(key)
+}
+
+func (t *SyncTrie) KeysWithCommonPrefix(prefix string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.trie.KeysWithCommonPrefix(prefix)
+}
+
+// Walk holds the read lock until the walk ends, so visit must not modify the trie.
+func (t *SyncTrie) Walk(prefix string, visit func(key string) bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	t.trie.Walk(prefix, visit)
+}
+
+func (t *SyncTrie) CountWithPrefix(prefix string) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.trie.CountWithPrefix(prefix)
+}
+
+func (t *SyncTrie) Size() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.trie.Size()
+}
+
+func (t *SyncTrie) Enumerate() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.trie.Enumerate()
+}
+
+// Anagrams looks up anagrams in the wrapped trie, or returns none if it can't look them up.
+func (t *SyncTrie) Anagrams(letters string, subset bool) []string {
+	finder, ok := t.trie.(anagramFinder)
+	if !ok {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return finder.Anagrams(letters, subset)
+}
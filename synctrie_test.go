@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSyncTrieConcurrentInsert(t *testing.T) {
+	trie := newSyncTrie(newTrieNode())
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				trie.Insert("word" + strconv.Itoa(i*100+j))
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				trie.Contains("word" + strconv.Itoa(i*100+j))
+				trie.CountWithPrefix("word")
+			}
+		}(i)
+	}
+	wg.Wait()
+	if actual := trie.Size(); actual != 800 {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", 800, actual)
+	}
+	for i := 0; i < 800; i++ {
+		if !trie.Contains("word" + strconv.Itoa(i)) {
+			t.Fatalf("Expected trie to contain word%d", i)
+		}
+	}
+}
+
+// TestSyncTrieInsertWhileChecking adds words to the dictionary while a document is being checked,
+// which spawns goroutines that read the trie concurrently.
+func TestSyncTrieInsertWhileChecking(t *testing.T) {
+	trie := newSyncTrie(newTrieNode())
+	trie.InsertAll(strings.NewReader("the\nquick\nbrown\nfox"))
+	spellcheck := newSpellcheckFromTrie(3, trie)
+	document := strings.Repeat("The quick brown fox jumps. The fox learned.\n", 50)
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			trie.Insert("learned" + strconv.Itoa(i))
+		}
+		trie.Insert("jumps")
+		done <- true
+	}()
+	for range spellcheck.CheckReader(strings.NewReader(document)) {
+	}
+	<-done
+	if !trie.Contains("jumps") || trie.Size() != 105 {
+		t.Fatalf("Expected learned words to be added, got %d keys", trie.Size())
+	}
+}
+
+// TestSyncTrieInsertAllSlowReader checks that lookups aren't blocked while a word list is still
+// being read.
+func TestSyncTrieInsertAllSlowReader(t *testing.T) {
+	trie := newSyncTrie(newTrieNode())
+	trie.Insert("the")
+	r, w := io.Pipe()
+	done := make(chan LoadReport)
+	go func() {
+		done <- trie.InsertAll(r)
+	}()
+	fmt.Fprintln(w, "quick")
+	if !trie.Contains("the") || trie.Contains("quick") {
+		t.Fatalf("Expected only the loaded words while the list is read, got %q", trie.Enumerate())
+	}
+	fmt.Fprintln(w, "brown")
+	w.Close()
+	if report := <-done; report.count != 2 || !trie.Contains("brown") {
+		t.Fatalf("Expected 2 words to be added, got %+v and %q", report, trie.Enumerate())
+	}
+}
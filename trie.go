@@ -1,167 +1,81 @@
 package main
 
 import (
-	"bufio"
+	"fmt"
 	"io"
-	"slices"
-	"strings"
 )
 
+// TrieNode is a trie of keys without values. It wraps a ValueTrie, which provides the lookups,
+// adapting it to the Trie interface.
 type TrieNode struct {
-	isKey    bool
-	children map[rune]*TrieNode
+	*ValueTrie[struct{}]
 }
 
 func newTrieNode() *TrieNode {
-	return &TrieNode{
-		isKey:    false,
-		children: make(map[rune]*TrieNode),
-	}
+	return &TrieNode{newValueTrie[struct{}]()}
 }
 
 type Trie interface {
 	Insert(key string) bool
-	InsertAll(r io.Reader)
+	InsertAll(r io.Reader) LoadReport
+	Delete(key string) bool
+	DeleteAll(r io.Reader) LoadReport
 	Contains(key string) bool
 	LongestPrefix(key string) string
 	KeysWithCommonPrefix(prefix string) []string
+	// Walk calls visit with each key starting with prefix in lexicographic order, generating keys
+	// lazily and stopping as soon as visit returns false.
+	Walk(prefix string, visit func(key string) bool)
+	// CountWithPrefix returns the number of keys starting with prefix, without enumerating them.
+	CountWithPrefix(prefix string) int
+	Size() int
 	Enumerate() []string
 }
 
-func (t *TrieNode) Contains(key string) bool {
-	currentNode := t
-	searchChars := []rune(key)
-	for len(searchChars) >= 1 {
-		c := searchChars[0]
-		child, hasChild := currentNode.children[c]
-		if hasChild {
-			currentNode = child
-			searchChars = searchChars[1:]
-		} else {
-			return false
-		}
-	}
-	return currentNode.isKey
+// trieImplementations are the dictionary representations that can back a TrieSpellcheck.
+var trieImplementations = map[string]func() Trie{
+	"trie":       func() Trie { return newTrieNode() },
+	"radix":      func() Trie { return newRadixNode() },
+	"dawg":       func() Trie { return newDawgNode() },
+	"sync":       func() Trie { return newSyncTrie(newTrieNode()) },
+	"persistent": func() Trie { return newVersionedTrie() },
+	"bloom":      func() Trie { return newFilteredTrie(newTrieNode()) },
 }
 
-func (t *TrieNode) addNewBranch(chars []rune) {
-	currentNode := t
+// membershipDictionary names the MembershipFilter, which is kept apart from trieImplementations
+// because it only answers Contains.
+const membershipDictionary = "membership"
 
-	for len(chars) >= 1 {
-		c := chars[0]
-		currentNode.children[c] = newTrieNode()
-		currentNode = currentNode.children[c]
-		chars = chars[1:]
+func getTrieImplementation(name string) (func() Trie, error) {
+	if name == membershipDictionary {
+		return func() Trie { return newMembershipFilter() }, nil
 	}
-	currentNode.isKey = true
-
-}
-func (t *TrieNode) Insert(s string) bool {
-	currentNode := t
-	searchChars := []rune(s)
-	for len(searchChars) >= 1 {
-		c := searchChars[0]
-		child, hasChild := currentNode.children[c]
-		if hasChild {
-			currentNode = child
-			searchChars = searchChars[1:]
-		} else {
-			currentNode.addNewBranch(searchChars)
-		}
+	newTrie, ok := trieImplementations[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown dictionary '%s'; expected one of trie, radix, dawg, sync, persistent, bloom, membership", name)
 	}
-	currentNode.isKey = true
-	return currentNode.isKey
+	return newTrie, nil
 }
 
-func (t *TrieNode) LongestPrefix(s string) string {
-	if len(s) == 0 {
-		return ""
-	}
-	currentNode := t
-	chars := []rune(s)
-	longestPrefix := make([]rune, 0)
-	for len(chars) > 0 && len(currentNode.children) > 0 {
-		c := chars[0]
-		child, hasChild := currentNode.children[c]
-		if hasChild {
-			longestPrefix = append(longestPrefix, c)
-			currentNode = child
-			chars = chars[1:]
-		} else {
-			break
-		}
-	}
-	return string(longestPrefix)
-}
-
-func (t *TrieNode) KeysWithCommonPrefix(s string) []string {
-	if len(s) == 0 {
-		return []string{}
-	}
-	currentNode := t
-	var keys []string
-	chars := []rune(s)
-	longestPrefix := make([]rune, 0)
-	for len(chars) > 0 && len(currentNode.children) > 0 {
-		c := chars[0]
-		child, hasChild := currentNode.children[c]
-		if hasChild {
-			longestPrefix = append(longestPrefix, c)
-			currentNode = child
-			chars = chars[1:]
-		} else {
-			break
-		}
-	}
-	if len(longestPrefix) > 0 {
-		keys = currentNode.Enumerate()
-
-		longestPrefixStr := string(longestPrefix)
-		slices.Sort(keys)
-		for i := 0; i < len(keys); i++ {
-			keys[i] = longestPrefixStr + keys[i]
-		}
-		return keys
-	} else {
-		return []string{}
-	}
-
+func (t *TrieNode) Insert(s string) bool {
+	t.Put(s, struct{}{})
+	return true
 }
 
-type NodePath struct {
-	prefix string
-	node   *TrieNode
+func (t *TrieNode) InsertAll(r io.Reader) LoadReport {
+	return readWordList(r, func(word string) {
+		_ = t.Insert(word)
+	})
 }
 
-func (t *TrieNode) Enumerate() []string {
-	var neighborStack = make(stack[NodePath], 0)
-	var enumeration []string
-	var prefix = ""
-	neighborStack.push(NodePath{prefix, t})
-
-	for neighborStack.size() > 0 {
-		currentPath := neighborStack.pop()
-		if currentPath.node.isKey {
-			enumeration = append(enumeration, currentPath.prefix)
-		}
-		for c, neighbor := range currentPath.node.children {
-			neighborStack.push(
-				NodePath{
-					prefix: currentPath.prefix + string(c),
-					node:   neighbor,
-				})
-		}
-	}
-	return enumeration
+func (t *TrieNode) Walk(prefix string, visit func(key string) bool) {
+	t.ValueTrie.Walk(prefix, func(key string, _ struct{}) bool {
+		return visit(key)
+	})
 }
 
-func (t *TrieNode) String() string {
-	return strings.Join(t.Enumerate(), "\n")
-}
-func (t *TrieNode) InsertAll(r io.Reader) {
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		word := scanner.Text()
-		_ = t.Insert(word)
-	}
+func (t *TrieNode) DeleteAll(r io.Reader) LoadReport {
+	return readWordList(r, func(word string) {
+		_ = t.Delete(word)
+	})
 }
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"math/rand"
 	"os"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -232,3 +234,191 @@ func TestInsertAll(t *testing.T) {
 		}
 	}
 }
+
+func TestDelete(t *testing.T) {
+	var trie Trie = newTrieNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"ant", "anthem", "anteater"}, "\n")))
+	if !trie.Delete("anthem") {
+		t.Fatalf("Expected anthem to be deleted")
+	}
+	if trie.Contains("anthem") {
+		t.Fatalf("Did not expect trie to contain anthem")
+	}
+	for _, key := range []string{"ant", "anteater"} {
+		if !trie.Contains(key) {
+			t.Fatalf("Expected trie to contain %v\n", key)
+		}
+	}
+	expected := []string{"ant", "anteater"}
+	actual := trie.KeysWithCommonPrefix("ant")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, actual)
+	}
+}
+
+func TestDelete_Prefix(t *testing.T) {
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"ant", "anthem"}, "\n")))
+	if !trie.Delete("ant") {
+		t.Fatalf("Expected ant to be deleted")
+	}
+	if trie.Contains("ant") || !trie.Contains("anthem") {
+		t.Fatalf("Expected only anthem to remain, got %v", trie.Enumerate())
+	}
+	if !trie.Delete("anthem") {
+		t.Fatalf("Expected anthem to be deleted")
+	}
+	if len(trie.children) != 0 {
+		t.Fatalf("Expected empty branches to be pruned, got %d children", len(trie.children))
+	}
+}
+
+func TestDelete_Missing(t *testing.T) {
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"anthem"}, "\n")))
+	for _, key := range []string{"ant", "anthems", "blarg"} {
+		if trie.Delete(key) {
+			t.Fatalf("Did not expect %v to be deleted", key)
+		}
+	}
+	if !trie.Contains("anthem") {
+		t.Fatalf("Expected trie to contain anthem")
+	}
+}
+
+func TestDeleteAll(t *testing.T) {
+	trie := newTrieNode()
+	trie.InsertAll(strings.NewReader(strings.Join([]string{"a", "ab", "abc", "b"}, "\n")))
+	trie.DeleteAll(strings.NewReader(strings.Join([]string{"ab", "b"}, "\n")))
+	expected := []string{"a", "abc"}
+	actual := trie.KeysWithCommonPrefix("a")
+	if !reflect.DeepEqual(actual, expected) || trie.Contains("b") {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, trie.Enumerate())
+	}
+}
+
+func BenchmarkRadixContains(b *testing.B) {
+	f, err := os.Open("words.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func(f *os.File) {
+		err := f.Close()
+		if err != nil {
+
+		}
+	}(f)
+	trie := newTrieNode()
+	trie.InsertAll(f)
+	radix := newRadixNode()
+	for _, word := range trie.Enumerate() {
+		radix.Insert(word)
+	}
+	lookupWords := pickRandomWords(10, trie)
+	l := len(lookupWords)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		word := lookupWords[i%l]
+		radix.Contains(word)
+	}
+}
+
+// benchmarkMemory reports the heap retained by a dictionary built from words.txt.
+func benchmarkMemory(b *testing.B, newTrie func() Trie) {
+	words, err := os.ReadFile("words.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var before, after runtime.MemStats
+	var trie Trie
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		trie = newTrie()
+		trie.InsertAll(bytes.NewReader(words))
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+	}
+	b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc)), "heap-bytes")
+	runtime.KeepAlive(trie)
+}
+
+func BenchmarkTrieMemory(b *testing.B) {
+	benchmarkMemory(b, func() Trie { return newTrieNode() })
+}
+
+func BenchmarkRadixMemory(b *testing.B) {
+	benchmarkMemory(b, func() Trie { return newRadixNode() })
+}
+
+func BenchmarkDawgMemory(b *testing.B) {
+	benchmarkMemory(b, func() Trie { return newDawgNode() })
+}
+
+func TestWalk(t *testing.T) {
+	wordList := []string{"thesis", "these", "theseus", "the", "theses", "them", "zebra", "thé"}
+	for name, newTrie := range trieImplementations {
+		trie := newTrie()
+		trie.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+
+		var actual []string
+		trie.Walk("thes", func(key string) bool {
+			actual = append(actual, key)
+			return true
+		})
+		expected := []string{"these", "theses", "theseus", "thesis"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("%s\nExpected:\t%v\nActual:\t\t%v\n", name, expected, actual)
+		}
+
+		actual = nil
+		trie.Walk("th", func(key string) bool {
+			actual = append(actual, key)
+			return len(actual) < 3
+		})
+		expected = []string{"the", "them", "these"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("%s: expected walk to stop early\nExpected:\t%v\nActual:\t\t%v\n", name, expected, actual)
+		}
+
+		trie.Walk("thx", func(key string) bool {
+			t.Fatalf("%s: did not expect keys with prefix thx, got %v", name, key)
+			return true
+		})
+	}
+}
+
+func TestCountWithPrefix(t *testing.T) {
+	wordList := []string{"thesis", "these", "theseus", "the", "theses", "them", "zebra", "thé", "these"}
+	for name, newTrie := range trieImplementations {
+		trie := newTrie()
+		trie.InsertAll(strings.NewReader(strings.Join(wordList, "\n")))
+
+		if actual := trie.Size(); actual != 8 {
+			t.Fatalf("%s\nExpected:\t%v\nActual:\t\t%v\n", name, 8, actual)
+		}
+		counts := map[string]int{"": 8, "th": 7, "thes": 4, "these": 3, "thé": 1, "z": 1, "thx": 0, "zebras": 0}
+		for prefix, expected := range counts {
+			if actual := trie.CountWithPrefix(prefix); actual != expected {
+				t.Fatalf("%s: count with prefix %q\nExpected:\t%v\nActual:\t\t%v\n", name, prefix, expected, actual)
+			}
+		}
+	}
+}
+
+func TestCountWithPrefix_Delete(t *testing.T) {
+	for _, name := range []string{"trie", "radix", "sync", "persistent"} {
+		trie := trieImplementations[name]()
+		trie.InsertAll(strings.NewReader(strings.Join([]string{"ant", "anthem", "anther", "bee"}, "\n")))
+		trie.Delete("ant")
+		trie.Delete("anthem")
+		trie.Delete("anthem")
+		trie.Delete("antelope")
+		if actual := trie.CountWithPrefix("ant"); actual != 1 {
+			t.Fatalf("%s\nExpected:\t%v\nActual:\t\t%v\n", name, 1, actual)
+		}
+		if actual := trie.Size(); actual != 2 {
+			t.Fatalf("%s\nExpected:\t%v\nActual:\t\t%v\n", name, 2, actual)
+		}
+	}
+}
@@ -44,3 +44,35 @@ func chanToSortedSlice[T any](ch <-chan T, sortFunc func(a, b T) int) []T {
 	slices.SortFunc(slice, sortFunc)
 	return slice
 }
+
+func sliceToChan[T any](slice []T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, v := range slice {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+func reverseString(s string) string {
+	runes := []rune(s)
+	slices.Reverse(runes)
+	return string(runes)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// minInt stands in for the builtin min, which needs Go 1.21 while go.mod targets Go 1.18.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -82,3 +82,13 @@ func TestChanToSortedSlice(t *testing.T) {
 		}
 	}
 }
+
+func TestSliceToChan(t *testing.T) {
+	vals := []int{3, 1, 2}
+	result := chanToSlice(sliceToChan(vals))
+	for i := 0; i < len(vals); i++ {
+		if result[i] != vals[i] {
+			t.Fatalf("Expected %v, got %v", vals, result)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValueTrie is a trie that associates a value with each key, such as a word's frequency, part of
+// speech, replacement or source dictionary. TrieNode wraps a ValueTrie without values.
+type ValueTrie[V any] struct {
+	isKey bool
+	value V
+	// count is the number of keys in the subtree rooted at this node, including its own
+	count    int
+	children map[rune]*ValueTrie[V]
+}
+
+func newValueTrie[V any]() *ValueTrie[V] {
+	return &ValueTrie[V]{
+		isKey:    false,
+		children: make(map[rune]*ValueTrie[V]),
+	}
+}
+
+// find returns the node key leads to, or nil if no key starts with it.
+func (t *ValueTrie[V]) find(key string) *ValueTrie[V] {
+	currentNode := t
+	for _, c := range key {
+		child, hasChild := currentNode.children[c]
+		if !hasChild {
+			return nil
+		}
+		currentNode = child
+	}
+	return currentNode
+}
+
+func (t *ValueTrie[V]) Contains(key string) bool {
+	node := t.find(key)
+	return node != nil && node.isKey
+}
+
+// Get returns the value associated with key, and false if key is not present.
+func (t *ValueTrie[V]) Get(key string) (V, bool) {
+	node := t.find(key)
+	if node == nil || !node.isKey {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+func (t *ValueTrie[V]) addNewBranch(chars []rune) {
+	currentNode := t
+
+	for len(chars) >= 1 {
+		c := chars[0]
+		currentNode.children[c] = newValueTrie[V]()
+		currentNode = currentNode.children[c]
+		chars = chars[1:]
+	}
+	currentNode.isKey = true
+
+}
+
+// Put associates value with key, replacing any value it already had. It returns true if key was
+// not present before.
+func (t *ValueTrie[V]) Put(key string, value V) bool {
+	if node := t.find(key); node != nil && node.isKey {
+		node.value = value
+		return false
+	}
+	currentNode := t
+	searchChars := []rune(key)
+	for len(searchChars) >= 1 {
+		currentNode.count++
+		c := searchChars[0]
+		if _, hasChild := currentNode.children[c]; !hasChild {
+			currentNode.addNewBranch(searchChars)
+		}
+		currentNode = currentNode.children[c]
+		searchChars = searchChars[1:]
+	}
+	currentNode.count++
+	currentNode.isKey = true
+	currentNode.value = value
+	return true
+}
+
+func (t *ValueTrie[V]) CountWithPrefix(prefix string) int {
+	node := t.find(prefix)
+	if node == nil {
+		return 0
+	}
+	return node.count
+}
+
+// Size returns the number of keys in the trie.
+func (t *ValueTrie[V]) Size() int {
+	return t.count
+}
+
+func (t *ValueTrie[V]) LongestPrefix(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	currentNode := t
+	chars := []rune(s)
+	longestPrefix := make([]rune, 0)
+	for len(chars) > 0 && len(currentNode.children) > 0 {
+		c := chars[0]
+		child, hasChild := currentNode.children[c]
+		if hasChild {
+			longestPrefix = append(longestPrefix, c)
+			currentNode = child
+			chars = chars[1:]
+		} else {
+			break
+		}
+	}
+	return string(longestPrefix)
+}
+
+func (t *ValueTrie[V]) KeysWithCommonPrefix(s string) []string {
+	if len(s) == 0 {
+		return []string{}
+	}
+	currentNode := t
+	var keys []string
+	chars := []rune(s)
+	longestPrefix := make([]rune, 0)
+	for len(chars) > 0 && len(currentNode.children) > 0 {
+		c := chars[0]
+		child, hasChild := currentNode.children[c]
+		if hasChild {
+			longestPrefix = append(longestPrefix, c)
+			currentNode = child
+			chars = chars[1:]
+		} else {
+			break
+		}
+	}
+	if len(longestPrefix) > 0 {
+		keys = currentNode.Enumerate()
+
+		longestPrefixStr := string(longestPrefix)
+		slices.Sort(keys)
+		for i := 0; i < len(keys); i++ {
+			keys[i] = longestPrefixStr + keys[i]
+		}
+		return keys
+	} else {
+		return []string{}
+	}
+
+}
+
+// Walk is like Trie.Walk, but also passes each key's value to visit.
+func (t *ValueTrie[V]) Walk(prefix string, visit func(key string, value V) bool) {
+	node := t.find(prefix)
+	if node == nil {
+		return
+	}
+	node.walk([]byte(prefix), visit)
+}
+
+func (t *ValueTrie[V]) walk(key []byte, visit func(key string, value V) bool) bool {
+	if t.isKey && !visit(string(key), t.value) {
+		return false
+	}
+	chars := make([]rune, 0, len(t.children))
+	for c := range t.children {
+		chars = append(chars, c)
+	}
+	slices.Sort(chars)
+	for _, c := range chars {
+		if !t.children[c].walk(utf8.AppendRune(key, c), visit) {
+			return false
+		}
+	}
+	return true
+}
+
+type NodePath[V any] struct {
+	prefix string
+	node   *ValueTrie[V]
+}
+
+func (t *ValueTrie[V]) Enumerate() []string {
+	var neighborStack = make(stack[NodePath[V]], 0)
+	var enumeration []string
+	var prefix = ""
+	neighborStack.push(NodePath[V]{prefix, t})
+
+	for neighborStack.size() > 0 {
+		currentPath := neighborStack.pop()
+		if currentPath.node.isKey {
+			enumeration = append(enumeration, currentPath.prefix)
+		}
+		for c, neighbor := range currentPath.node.children {
+			neighborStack.push(
+				NodePath[V]{
+					prefix: currentPath.prefix + string(c),
+					node:   neighbor,
+				})
+		}
+	}
+	return enumeration
+}
+
+func (t *ValueTrie[V]) String() string {
+	return strings.Join(t.Enumerate(), "\n")
+}
+
+// Delete removes key and its value from the trie, pruning branches left without keys. It returns
+// false if key was not present.
+func (t *ValueTrie[V]) Delete(key string) bool {
+	currentNode := t
+	path := []*ValueTrie[V]{t}
+	chars := []rune(key)
+	for _, c := range chars {
+		child, hasChild := currentNode.children[c]
+		if !hasChild {
+			return false
+		}
+		currentNode = child
+		path = append(path, currentNode)
+	}
+	if !currentNode.isKey {
+		return false
+	}
+	currentNode.isKey = false
+	var zero V
+	currentNode.value = zero
+	for _, node := range path {
+		node.count--
+	}
+	for i := len(chars) - 1; i >= 0; i-- {
+		node := path[i+1]
+		if node.count > 0 {
+			break
+		}
+		delete(path[i].children, chars[i])
+	}
+	return true
+}
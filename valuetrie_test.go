@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValueTriePutGet(t *testing.T) {
+	trie := newValueTrie[int]()
+	if !trie.Put("the", 120) || !trie.Put("them", 40) || !trie.Put("thé", 1) {
+		t.Fatalf("Expected new keys to be added")
+	}
+	if trie.Put("the", 125) {
+		t.Fatalf("Expected the to already be present")
+	}
+	expected := map[string]int{"the": 125, "them": 40, "thé": 1}
+	for key, value := range expected {
+		if actual, ok := trie.Get(key); !ok || actual != value {
+			t.Fatalf("%s\nExpected:\t%v\nActual:\t\t%v\n", key, value, actual)
+		}
+	}
+	if _, ok := trie.Get("th"); ok {
+		t.Fatalf("Did not expect a value for the prefix th")
+	}
+	if actual := trie.Size(); actual != 3 {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", 3, actual)
+	}
+}
+
+func TestValueTrieDelete(t *testing.T) {
+	trie := newValueTrie[string]()
+	trie.Put("teh", "the")
+	trie.Put("tehm", "them")
+	if !trie.Delete("teh") || trie.Delete("teh") {
+		t.Fatalf("Expected teh to be deleted once")
+	}
+	if _, ok := trie.Get("teh"); ok {
+		t.Fatalf("Did not expect a value for a deleted key")
+	}
+	if actual, _ := trie.Get("tehm"); actual != "them" {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", "them", actual)
+	}
+	trie.Put("teh", "ten")
+	if actual, _ := trie.Get("teh"); actual != "ten" {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", "ten", actual)
+	}
+}
+
+func TestValueTrieWalk(t *testing.T) {
+	trie := newValueTrie[int]()
+	for i, key := range []string{"these", "the", "theses", "them", "zebra"} {
+		trie.Put(key, i)
+	}
+	var keys []string
+	var values []int
+	trie.Walk("the", func(key string, value int) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+	if expected := []string{"the", "them", "these", "theses"}; !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, keys)
+	}
+	if expected := []int{1, 3, 0, 2}; !reflect.DeepEqual(values, expected) {
+		t.Fatalf("\nExpected:\t%v\nActual:\t\t%v\n", expected, values)
+	}
+}
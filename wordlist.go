@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LoadReport summarizes a word list read by InsertAll or DeleteAll.
+type LoadReport struct {
+	// count is the number of words read, including any already in the dictionary
+	count int
+	// skipped is the number of blank and comment lines
+	skipped int
+	// err is the error that stopped reading, such as a line too long to scan, or nil if the whole
+	// list was read
+	err error
+}
+
+var (
+	errReadOnly = errors.New("dictionary is read-only")
+	errNoDelete = errors.New("dictionary does not support deleting words")
+)
+
+// wordListComment starts a comment, which runs to the end of the line.
+const wordListComment = "#"
+
+// readWordList calls add with each word in a newline-separated word list. Lines may end in CRLF,
+// surrounding whitespace and comments are removed, and lines left blank are skipped.
+func readWordList(r io.Reader, add func(word string)) LoadReport {
+	var report LoadReport
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		word := scanner.Text()
+		if i := strings.Index(word, wordListComment); i >= 0 {
+			word = word[:i]
+		}
+		word = strings.TrimSpace(word)
+		if word == "" {
+			report.skipped++
+			continue
+		}
+		add(word)
+		report.count++
+	}
+	if err := scanner.Err(); err != nil {
+		report.err = fmt.Errorf("word list line %d: %w", lineNum+1, err)
+	}
+	return report
+}
+
+// readWords returns the words in a newline-separated word list, as read by readWordList.
+func readWords(r io.Reader) ([]string, LoadReport) {
+	var words []string
+	report := readWordList(r, func(word string) {
+		words = append(words, word)
+	})
+	return words, report
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadWordList(t *testing.T) {
+	input := "# team words\r\nthe\r\n\r\nthem  # plural\r\n   \r\n  these\nzebra"
+	words, report := readWords(strings.NewReader(input))
+	expected := []string{"the", "them", "these", "zebra"}
+	if !reflect.DeepEqual(words, expected) {
+		t.Fatalf("\nExpected:\t%q\nActual:\t\t%q\n", expected, words)
+	}
+	if report.count != 4 || report.skipped != 3 || report.err != nil {
+		t.Fatalf("Expected 4 words and 3 skipped lines, got %+v", report)
+	}
+}
+
+func TestReadWordList_LineTooLong(t *testing.T) {
+	input := "the\nthem\n" + strings.Repeat("a", bufio.MaxScanTokenSize+1) + "\nzebra"
+	_, report := readWords(strings.NewReader(input))
+	if !errors.Is(report.err, bufio.ErrTooLong) {
+		t.Fatalf("Expected %v, got %v", bufio.ErrTooLong, report.err)
+	}
+	if !strings.Contains(report.err.Error(), "line 3") || report.count != 2 {
+		t.Fatalf("Expected the error on line 3 after 2 words, got %v after %d", report.err, report.count)
+	}
+}
+
+func TestInsertAll_Report(t *testing.T) {
+	input := "the\r\nthem\r\n\r\n# comment\r\nthe"
+	for name, newTrie := range trieImplementations {
+		trie := newTrie()
+		report := trie.InsertAll(strings.NewReader(input))
+		if report.count != 3 || report.skipped != 2 || report.err != nil {
+			t.Fatalf("%s: expected 3 words and 2 skipped lines, got %+v", name, report)
+		}
+		if trie.Size() != 2 || !trie.Contains("them") || trie.Contains("them\r") || trie.Contains("") {
+			t.Fatalf("%s: expected CRs and blank lines to be stripped, got %q", name, trie.Enumerate())
+		}
+	}
+	if report := newPersistentTrie().InsertAll(strings.NewReader(input)); !errors.Is(report.err, errReadOnly) {
+		t.Fatalf("Expected %v, got %v", errReadOnly, report.err)
+	}
+}
+
+func TestDeleteAll_Report(t *testing.T) {
+	input := "the\r\nthem  # plural\r\n\r\nzebra"
+	for name, newTrie := range trieImplementations {
+		trie := newTrie()
+		trie.InsertAll(strings.NewReader(input))
+		report := trie.DeleteAll(strings.NewReader("the\r\n# comment\r\nthem  # plural\r\n"))
+		if name == "dawg" {
+			if !errors.Is(report.err, errReadOnly) || trie.Size() != 3 {
+				t.Fatalf("%s: expected %v and no keys deleted, got %v and %q", name, errReadOnly, report.err, trie.Enumerate())
+			}
+			continue
+		}
+		if report.count != 2 || report.skipped != 1 || report.err != nil {
+			t.Fatalf("%s: expected 2 words and 1 skipped line, got %+v", name, report)
+		}
+		if keys := trie.Enumerate(); !reflect.DeepEqual(keys, []string{"zebra"}) {
+			t.Fatalf("%s: expected only zebra left, got %q", name, keys)
+		}
+	}
+}